@@ -1,17 +1,19 @@
 package beater
 
 import (
-	"bytes"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/cfgfile"
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
 	"github.com/elastic/beats/libbeat/publisher"
 
 	"sflowbeat/sflow"
+	"sflowbeat/sflow/records"
 )
 
 type Flowbeat struct {
@@ -25,7 +27,9 @@ type Flowbeat struct {
 }
 
 type FlowConfig struct {
-	Listen *string
+	Listen           *string
+	ApplicationLayer *bool    `yaml:"application_layer"`
+	HTTPPorts        []uint16 `yaml:"http_ports"`
 }
 
 type ConfigSettings struct {
@@ -50,6 +54,17 @@ func (fb *Flowbeat) Config(b *beat.Beat) error {
 		fb.listen = ":6343"
 	}
 
+	if fb.FbConfig.Input.ApplicationLayer != nil {
+		records.DecodeApplicationLayer = *fb.FbConfig.Input.ApplicationLayer
+	}
+
+	if fb.FbConfig.Input.HTTPPorts != nil {
+		records.HTTPPorts = map[uint16]bool{}
+		for _, port := range fb.FbConfig.Input.HTTPPorts {
+			records.HTTPPorts[port] = true
+		}
+	}
+
 	logp.Debug("flowbeat", "Init flowbeat")
 	logp.Debug("flowbeat", "Listening on %s\n", fb.listen)
 
@@ -72,11 +87,38 @@ func (fb *Flowbeat) Setup(b *beat.Beat) error {
 	return nil
 }
 
+// received is a UDP packet and the datagram decoded from it. The records of the datagram refer to the
+// packet buffer, so both are handed back to their pools only once all events of the datagram are published.
+type received struct {
+	buffer []byte
+	dgram  *sflow.Datagram
+}
+
+var receivedPool = sync.Pool{New: func() interface{} {
+	return &received{buffer: make([]byte, sflow.MaximumDatagramLength)}
+}}
+
+// Completed is signalled by the publisher once all events of the datagram are sent
+func (r *received) Completed() {
+	r.release()
+}
+
+// Failed is signalled by the publisher if any event of the datagram could not be sent
+func (r *received) Failed() {
+	r.release()
+}
+
+func (r *received) release() {
+	if r.dgram != nil {
+		r.dgram.Release()
+		r.dgram = nil
+	}
+	receivedPool.Put(r)
+}
+
 func (fb *Flowbeat) Run(b *beat.Beat) error {
-	var err error
-	packetbuffer := make([]byte, 65535)
-	reader := bytes.NewReader(packetbuffer)
-	decoder := sflow.NewDecoder(reader)
+	decoder := sflow.NewDecoder(nil)
+	decoder.SetDiagnostics(sflow.DiagnosticsFunc(logDiagnostic))
 
 	for {
 		select {
@@ -85,21 +127,23 @@ func (fb *Flowbeat) Run(b *beat.Beat) error {
 		default:
 		}
 
-		reader.Seek(0, 0) //Reset the reader on our buffer
-
 		// Listen for sflow datagrams
-		size, addr, err := fb.conn.ReadFromUDP(packetbuffer)
+		packet := receivedPool.Get().(*received)
+		size, addr, err := fb.conn.ReadFromUDP(packet.buffer)
 		logp.Debug("flowbeat", "Received UDP Packet with Size: %d", size)
 		if err != nil {
 			return err
 		}
 
-		dgram, err := decoder.Decode()
+		dgram, err := decoder.DecodeBytes(packet.buffer[:size])
 		if err != nil {
 			logp.Warn("Error decoding sflow packet: %s", err)
+			packet.release()
 			continue
 		}
+		packet.dgram = dgram
 
+		events := make([]common.MapStr, 0, len(dgram.Samples))
 		for _, sample := range dgram.Samples {
 			event := common.MapStr{
 				"@timestamp":     common.Time(time.Now()),
@@ -120,6 +164,12 @@ func (fb *Flowbeat) Run(b *beat.Beat) error {
 				event["drops"] = sample.Drops
 				event["input"] = sample.Input
 				event["output"] = sample.Output
+
+				// Sampled HTTP transactions are published as access log like events
+				if http := httpEvent(sample.Records); http != nil {
+					event["type"] = "http"
+					event["http"] = http
+				}
 			case sflow.TypeCounterSample:
 				event["type"] = "counter"
 				sample := sample.(*sflow.CounterSample)
@@ -134,13 +184,26 @@ func (fb *Flowbeat) Run(b *beat.Beat) error {
 
 			for _, record := range sample.GetRecords() {
 				event[record.RecordName()] = record
+
+				if raw, ok := record.(*records.RawPacketFlow); ok && raw.DecodedHeader != nil {
+					event["packet"] = packetEvent(raw.DecodedHeader)
+				}
 			}
 
-			fb.events.PublishEvent(event)
+			events = append(events, event)
+		}
+
+		if len(events) == 0 {
+			packet.release()
+			continue
 		}
-	}
 
-	return err
+		// The events refer to the datagram until the output has sent all of them
+		signal := outputs.NewSplitSignaler(packet, len(events))
+		for _, event := range events {
+			fb.events.PublishEvent(event, publisher.Signal(signal))
+		}
+	}
 }
 
 func (fb *Flowbeat) Cleanup(b *beat.Beat) error {
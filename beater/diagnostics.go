@@ -0,0 +1,25 @@
+package beater
+
+import (
+	"expvar"
+
+	"github.com/elastic/beats/libbeat/logp"
+
+	"sflowbeat/sflow"
+)
+
+// Counts of the decode diagnostics by kind, which can be retrieved through the expvar web interface.
+var diagnosticCounts = expvar.NewMap("flowbeatDecodeDiagnostics")
+
+// logDiagnostic counts the diagnostics of the sFlow decoder by kind and logs them.
+// Issues with the sampled packet headers are common and only logged in debug mode.
+func logDiagnostic(d sflow.Diagnostic) {
+	diagnosticCounts.Add(d.Kind.String(), 1)
+
+	switch d.Kind {
+	case sflow.DiagnosticTruncatedHeader, sflow.DiagnosticUnknownProtocol:
+		logp.Debug("flowbeat", "%s", d)
+	default:
+		logp.Warn("%s", d)
+	}
+}
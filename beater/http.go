@@ -0,0 +1,111 @@
+package beater
+
+import (
+	"github.com/elastic/beats/libbeat/common"
+
+	"sflowbeat/sflow/records"
+)
+
+// httpEvent maps the records of a sampled HTTP transaction onto access log like fields.
+// It returns nil if the records do not contain a HTTP request. The records are decoded by
+// DecodeBytes, which returns them as pointers.
+func httpEvent(recs []records.Record) common.MapStr {
+	var request *records.HTTPRequestFlow
+	var client, server, proxy, timing common.MapStr
+
+	for _, record := range recs {
+		switch rec := record.(type) {
+		case *records.HTTPRequestFlow:
+			request = rec
+		case *records.ExtendedSocketIPv4Flow:
+			// The socket is reported by the server, so the remote end is the client
+			client = common.MapStr{"ip": rec.RemoteIP, "port": rec.RemotePort}
+			server = common.MapStr{"ip": rec.LocalIP, "port": rec.LocalPort}
+		case *records.ExtendedSocketIPv6Flow:
+			client = common.MapStr{"ip": rec.RemoteIP, "port": rec.RemotePort}
+			server = common.MapStr{"ip": rec.LocalIP, "port": rec.LocalPort}
+		case *records.ExtendedNavTimingFlow:
+			timing = navTimingEvent(*rec)
+		case *records.ExtendedProxyRequestFlow:
+			if proxy == nil {
+				proxy = common.MapStr{}
+			}
+			proxy["url"] = rec.URI.String()
+			proxy["host"] = rec.Host.String()
+		case *records.ExtendedProxySocketIPv4Flow:
+			// The proxy socket is the connection to the downstream server
+			if proxy == nil {
+				proxy = common.MapStr{}
+			}
+			proxy["ip"] = rec.Socket.RemoteIP
+			proxy["port"] = rec.Socket.RemotePort
+		case *records.ExtendedProxySocketIPv6Flow:
+			if proxy == nil {
+				proxy = common.MapStr{}
+			}
+			proxy["ip"] = rec.Socket.RemoteIP
+			proxy["port"] = rec.Socket.RemotePort
+		}
+	}
+
+	if request == nil {
+		return nil
+	}
+
+	event := common.MapStr{
+		"method":        request.MethodName,
+		"protocol":      request.ProtocolName,
+		"url":           request.URI.String(),
+		"host":          request.Host.String(),
+		"referer":       request.Referer.String(),
+		"userAgent":     request.UserAgent.String(),
+		"xForwardedFor": request.XFF.String(),
+		"authUser":      request.AuthUser.String(),
+		"mimeType":      request.MimeType.String(),
+		"status":        request.Status,
+		"requestBytes":  request.ReqBytes,
+		"responseBytes": request.RespBytes,
+		"duration":      request.Duration,
+	}
+
+	if client != nil {
+		event["client"] = client
+		event["server"] = server
+	}
+
+	if proxy != nil {
+		event["proxy"] = proxy
+	}
+
+	if timing != nil {
+		event["navTiming"] = timing
+	}
+
+	return event
+}
+
+// navTimingEvent maps the page timing of a sampled HTTP transaction onto the
+// durations (in milliseconds) of the individual phases of loading the page.
+func navTimingEvent(t records.ExtendedNavTimingFlow) common.MapStr {
+	return common.MapStr{
+		"type":          t.TypeName,
+		"redirectCount": t.RedirectCount,
+		"redirect":      timingSpan(t.RedirectStart, t.RedirectEnd),
+		"dns":           timingSpan(t.DomainLookupStart, t.DomainLookupEnd),
+		"connect":       timingSpan(t.ConnectStart, t.ConnectEnd),
+		"request":       timingSpan(t.RequestStart, t.ResponseStart),
+		"response":      timingSpan(t.ResponseStart, t.ResponseEnd),
+		"dom":           timingSpan(t.DomLoading, t.DomComplete),
+		"load":          timingSpan(t.LoadEventStart, t.LoadEventEnd),
+		"total":         timingSpan(t.NavigationStart, t.LoadEventEnd),
+	}
+}
+
+// timingSpan returns the time between two navigation timing marks or 0 if a
+// mark was not recorded by the browser.
+func timingSpan(start, end uint32) uint32 {
+	if start == 0 || end < start {
+		return 0
+	}
+	return end - start
+}
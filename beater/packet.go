@@ -0,0 +1,121 @@
+package beater
+
+import (
+	"github.com/elastic/beats/libbeat/common"
+
+	"sflowbeat/sflow/records"
+)
+
+// packetEvent maps the decoded sampled header of a RawPacketFlow onto fixed event fields.
+// Fields of layers which are not present in the sampled header are left out.
+func packetEvent(p *records.DecodedPacket) common.MapStr {
+	event := common.MapStr{
+		"offset": p.Offset,
+	}
+
+	if p.Link != nil && p.Link.Ethernet != nil {
+		event["srcMac"] = p.Link.Ethernet.SrcMac
+		event["dstMac"] = p.Link.Ethernet.DstMac
+	}
+
+	if len(p.VLANs) > 0 {
+		vlans := make([]uint16, len(p.VLANs))
+		for i, tag := range p.VLANs {
+			vlans[i] = tag.ID
+		}
+		event["vlan"] = vlans
+	}
+
+	if p.Network != nil {
+		switch {
+		case p.Network.IPv4 != nil:
+			ip := p.Network.IPv4
+			event["ipVersion"] = 4
+			event["ttl"] = ip.TTL
+			event["dscp"] = ip.DSCPName
+			event["ecn"] = ip.ECNName
+			event["ipProtocol"] = ip.Protocol
+		case p.Network.IPv6 != nil:
+			ip := p.Network.IPv6
+			event["ipVersion"] = 6
+			event["ttl"] = ip.TTL
+			event["dscp"] = ip.DSCPName
+			event["ecn"] = ip.ECNName
+			event["ipProtocol"] = ip.Protocol
+			event["flowLabel"] = ip.FlowLabel
+		}
+
+		if src := p.SrcIP(); src != nil {
+			event["srcIp"] = src
+			event["dstIp"] = p.DstIP()
+		}
+	}
+
+	if src, dst, ok := p.Ports(); ok {
+		event["srcPort"] = src
+		event["dstPort"] = dst
+	}
+
+	if p.Transport != nil {
+		if p.Transport.TCP != nil {
+			event["tcpFlags"] = p.Transport.TCP.FlagNames
+		}
+
+		icmp := p.Transport.ICMP
+		if icmp == nil {
+			icmp = p.Transport.ICMPv6
+		}
+		if icmp != nil {
+			event["icmpType"] = icmp.TypeName
+			event["icmpCode"] = icmp.CodeName
+		}
+	}
+
+	if p.Application != nil {
+		if dns := p.Application.DNS; dns != nil {
+			event["dns"] = common.MapStr{
+				"query": dns.QueryName,
+				"type":  dns.QueryTypeName,
+				"rcode": dns.RCodeName,
+			}
+		}
+
+		if tls := p.Application.TLS; tls != nil {
+			event["tls"] = common.MapStr{
+				"version":    tls.VersionName,
+				"serverName": tls.ServerName,
+				"alpn":       tls.ALPN,
+			}
+		}
+
+		if http := p.Application.HTTP; http != nil {
+			event["http"] = common.MapStr{
+				"method": http.Method,
+				"uri":    http.URI,
+				"host":   http.Host,
+			}
+		}
+	}
+
+	// Layers decoded by dissectors registered from outside of the records package
+	if len(p.Layers) > 0 {
+		event["layers"] = p.Layers
+	}
+
+	if p.Inner != nil {
+		event["inner"] = packetEvent(p.Inner)
+	}
+
+	if p.Unknown != nil {
+		event["unknownProtocol"] = common.MapStr{
+			"layer":    p.Unknown.Layer,
+			"protocol": p.Unknown.Protocol,
+		}
+	}
+
+	if len(p.Errors) > 0 {
+		event["decodeErrors"] = p.Errors
+	}
+
+	return event
+}
@@ -0,0 +1,55 @@
+package beater
+
+import (
+	"bytes"
+	"testing"
+
+	"sflowbeat/sflow/records"
+)
+
+// decodeTestPacket decodes the given sampled header as a RawPacketFlow and returns the decoded packet
+func decodeTestPacket(t *testing.T, protocol uint32, header []byte) *records.DecodedPacket {
+	rec := records.RawPacketFlow{
+		Protocol:    protocol,
+		FrameLength: uint32(len(header)),
+		HeaderSize:  uint32(len(header)),
+		Header:      header,
+	}
+
+	b := &bytes.Buffer{}
+	if err := rec.Encode(b); err != nil {
+		t.Fatal(err)
+	}
+
+	// Skip the record type and length
+	b.Next(8)
+
+	decoded, err := records.DecodeFlow(b, records.TypeRawPacketFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return decoded.(records.RawPacketFlow).DecodedHeader
+}
+
+func TestPacketEventIPv6ExtensionHeader(t *testing.T) {
+	header := []byte{
+		// IPv6 2001:db8::1 -> 2001:db8::2, Hop-by-Hop Options
+		0x60, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x40,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2,
+		// Hop-by-Hop Options, UDP, PadN
+		0x11, 0x00, 0x01, 0x04, 0x00, 0x00, 0x00, 0x00,
+		// UDP 5000 -> 6000
+		0x13, 0x88, 0x17, 0x70, 0x00, 0x08, 0x00, 0x00,
+	}
+
+	event := packetEvent(decodeTestPacket(t, records.HeaderProtocolIPv6, header))
+
+	if protocol := event["ipProtocol"]; protocol != uint8(records.IPProtocolUDP) {
+		t.Errorf("expected the IP protocol %d, got %v", records.IPProtocolUDP, protocol)
+	}
+	if src, dst := event["srcPort"], event["dstPort"]; src != uint16(5000) || dst != uint16(6000) {
+		t.Errorf("expected the ports 5000 and 6000, got %v and %v", src, dst)
+	}
+}
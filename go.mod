@@ -3,15 +3,12 @@ module sflowbeat
 go 1.15
 
 require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/elastic/beats v1.1.0
-	github.com/elastic/go-sysinfo v1.4.0 // indirect
-	github.com/elastic/go-ucfg v0.8.3 // indirect
-	github.com/fstelzer/sflow v0.0.0-20160305124437-3c379a5e74f8
 	github.com/garyburd/redigo v1.6.2 // indirect
-	github.com/gofrs/uuid v3.3.0+incompatible // indirect
-	github.com/mitchellh/hashstructure v1.1.0 // indirect
+	github.com/kr/pretty v0.1.0 // indirect
 	github.com/nranchev/go-libGeoIP v0.0.0-20170629073846-d6d4a9a4c7e8 // indirect
-	github.com/pkg/errors v0.9.1 // indirect
-	go.uber.org/zap v1.16.0 // indirect
+	github.com/stretchr/testify v1.4.0 // indirect
 	golang.org/x/sys v0.0.0-20201119102817-f84b799fce68 // indirect
+	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 )
@@ -3,9 +3,8 @@ package sflow
 import (
 	"encoding/binary"
 	"fmt"
-	"github.com/fstelzer/sflow/records"
 	"io"
-	"unsafe"
+	"sflowbeat/sflow/records"
 )
 
 // GenericInterfaceCounters is a generic switch counters record.
@@ -285,17 +284,18 @@ func (c HostNetCounters) RecordName() string {
 	return "HostNetCounters"
 }
 
+// The encoded lengths of the counter records
 var (
-	genericInterfaceCountersSize = uint32(unsafe.Sizeof(GenericInterfaceCounters{}))
-	ethernetCountersSize         = uint32(unsafe.Sizeof(EthernetCounters{}))
-	tokenRingCountersSize        = uint32(unsafe.Sizeof(TokenRingCounters{}))
-	vgCountersSize               = uint32(unsafe.Sizeof(VgCounters{}))
-	vlanCountersSize             = uint32(unsafe.Sizeof(VlanCounters{}))
-	processorCountersSize        = uint32(unsafe.Sizeof(ProcessorCounters{}))
-	hostCPUCountersSize          = uint32(unsafe.Sizeof(HostCPUCounters{}))
-	hostMemoryCountersSize       = uint32(unsafe.Sizeof(HostMemoryCounters{}))
-	hostDiskCountersSize         = uint32(unsafe.Sizeof(HostDiskCounters{}))
-	hostNetCountersSize          = uint32(unsafe.Sizeof(HostNetCounters{}))
+	genericInterfaceCountersSize = uint32(binary.Size(GenericInterfaceCounters{}))
+	ethernetCountersSize         = uint32(binary.Size(EthernetCounters{}))
+	tokenRingCountersSize        = uint32(binary.Size(TokenRingCounters{}))
+	vgCountersSize               = uint32(binary.Size(VgCounters{}))
+	vlanCountersSize             = uint32(binary.Size(VlanCounters{}))
+	processorCountersSize        = uint32(binary.Size(ProcessorCounters{}))
+	hostCPUCountersSize          = uint32(binary.Size(HostCPUCounters{}))
+	hostMemoryCountersSize       = uint32(binary.Size(HostMemoryCounters{}))
+	hostDiskCountersSize         = uint32(binary.Size(HostDiskCounters{}))
+	hostNetCountersSize          = uint32(binary.Size(HostNetCounters{}))
 )
 
 // RecordType returns the type of counter record.
@@ -304,13 +304,19 @@ func (c GenericInterfaceCounters) RecordType() int {
 }
 
 func decodeGenericInterfaceCountersRecord(r io.Reader, length uint32) (GenericInterfaceCounters, error) {
-	c := GenericInterfaceCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return GenericInterfaceCounters{}, records.ErrDecodingRecord
 	}
 
+	c := GenericInterfaceCounters{}
+	err := readGenericInterfaceCountersRecord(b, &c)
+	return c, err
+}
+
+// readGenericInterfaceCountersRecord decodes the record data held by b into c
+func readGenericInterfaceCountersRecord(b []byte, c *GenericInterfaceCounters) error {
 	fields := []interface{}{
 		&c.Index,
 		&c.Type,
@@ -333,7 +339,7 @@ func decodeGenericInterfaceCountersRecord(r io.Reader, length uint32) (GenericIn
 		&c.PromiscuousMode,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c GenericInterfaceCounters) Encode(w io.Writer) error {
@@ -359,13 +365,19 @@ func (c EthernetCounters) RecordType() int {
 }
 
 func decodeEthernetCountersRecord(r io.Reader, length uint32) (EthernetCounters, error) {
-	c := EthernetCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return EthernetCounters{}, records.ErrDecodingRecord
 	}
 
+	c := EthernetCounters{}
+	err := readEthernetCountersRecord(b, &c)
+	return c, err
+}
+
+// readEthernetCountersRecord decodes the record data held by b into c
+func readEthernetCountersRecord(b []byte, c *EthernetCounters) error {
 	fields := []interface{}{
 		&c.AlignmentErrors,
 		&c.FCSErrors,
@@ -382,7 +394,7 @@ func decodeEthernetCountersRecord(r io.Reader, length uint32) (EthernetCounters,
 		&c.SymbolErrors,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c EthernetCounters) Encode(w io.Writer) error {
@@ -408,13 +420,19 @@ func (c TokenRingCounters) RecordType() int {
 }
 
 func decodeTokenRingCountersRecord(r io.Reader, length uint32) (TokenRingCounters, error) {
-	c := TokenRingCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return TokenRingCounters{}, records.ErrDecodingRecord
 	}
 
+	c := TokenRingCounters{}
+	err := readTokenRingCountersRecord(b, &c)
+	return c, err
+}
+
+// readTokenRingCountersRecord decodes the record data held by b into c
+func readTokenRingCountersRecord(b []byte, c *TokenRingCounters) error {
 	fields := []interface{}{
 		&c.LineErrors,
 		&c.BurstErrors,
@@ -436,7 +454,7 @@ func decodeTokenRingCountersRecord(r io.Reader, length uint32) (TokenRingCounter
 		&c.FreqErrors,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c TokenRingCounters) Encode(w io.Writer) error {
@@ -462,13 +480,19 @@ func (c VgCounters) RecordType() int {
 }
 
 func decodeVgCountersRecord(r io.Reader, length uint32) (VgCounters, error) {
-	c := VgCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return VgCounters{}, records.ErrDecodingRecord
 	}
 
+	c := VgCounters{}
+	err := readVgCountersRecord(b, &c)
+	return c, err
+}
+
+// readVgCountersRecord decodes the record data held by b into c
+func readVgCountersRecord(b []byte, c *VgCounters) error {
 	fields := []interface{}{
 		&c.InHighPriorityFrames,
 		&c.InHighPriorityOctets,
@@ -486,7 +510,7 @@ func decodeVgCountersRecord(r io.Reader, length uint32) (VgCounters, error) {
 		&c.HCOutHighPriorityOctets,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c VgCounters) Encode(w io.Writer) error {
@@ -512,13 +536,19 @@ func (c VlanCounters) RecordType() int {
 }
 
 func decodeVlanCountersRecord(r io.Reader, length uint32) (VlanCounters, error) {
-	c := VlanCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return VlanCounters{}, records.ErrDecodingRecord
 	}
 
+	c := VlanCounters{}
+	err := readVlanCountersRecord(b, &c)
+	return c, err
+}
+
+// readVlanCountersRecord decodes the record data held by b into c
+func readVlanCountersRecord(b []byte, c *VlanCounters) error {
 	fields := []interface{}{
 		&c.ID,
 		&c.Octets,
@@ -528,7 +558,7 @@ func decodeVlanCountersRecord(r io.Reader, length uint32) (VlanCounters, error)
 		&c.Discards,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c VlanCounters) Encode(w io.Writer) error {
@@ -554,13 +584,19 @@ func (c ProcessorCounters) RecordType() int {
 }
 
 func decodeProcessorCountersRecord(r io.Reader, length uint32) (ProcessorCounters, error) {
-	c := ProcessorCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return ProcessorCounters{}, records.ErrDecodingRecord
 	}
 
+	c := ProcessorCounters{}
+	err := readProcessorCountersRecord(b, &c)
+	return c, err
+}
+
+// readProcessorCountersRecord decodes the record data held by b into c
+func readProcessorCountersRecord(b []byte, c *ProcessorCounters) error {
 	fields := []interface{}{
 		&c.CPU5s,
 		&c.CPU1m,
@@ -569,7 +605,7 @@ func decodeProcessorCountersRecord(r io.Reader, length uint32) (ProcessorCounter
 		&c.FreeMemory,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c ProcessorCounters) Encode(w io.Writer) error {
@@ -595,13 +631,19 @@ func (c HostCPUCounters) RecordType() int {
 }
 
 func decodeHostCPUCountersRecord(r io.Reader, length uint32) (HostCPUCounters, error) {
-	c := HostCPUCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return HostCPUCounters{}, records.ErrDecodingRecord
 	}
 
+	c := HostCPUCounters{}
+	err := readHostCPUCountersRecord(b, &c)
+	return c, err
+}
+
+// readHostCPUCountersRecord decodes the record data held by b into c
+func readHostCPUCountersRecord(b []byte, c *HostCPUCounters) error {
 	fields := []interface{}{
 		&c.Load1m,
 		&c.Load5m,
@@ -625,7 +667,7 @@ func decodeHostCPUCountersRecord(r io.Reader, length uint32) (HostCPUCounters, e
 		&c.CPUGuestNice,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c HostCPUCounters) Encode(w io.Writer) error {
@@ -651,13 +693,19 @@ func (c HostMemoryCounters) RecordType() int {
 }
 
 func decodeHostMemoryCountersRecord(r io.Reader, length uint32) (HostMemoryCounters, error) {
-	c := HostMemoryCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return HostMemoryCounters{}, records.ErrDecodingRecord
 	}
 
+	c := HostMemoryCounters{}
+	err := readHostMemoryCountersRecord(b, &c)
+	return c, err
+}
+
+// readHostMemoryCountersRecord decodes the record data held by b into c
+func readHostMemoryCountersRecord(b []byte, c *HostMemoryCounters) error {
 	fields := []interface{}{
 		&c.Total,
 		&c.Free,
@@ -672,7 +720,7 @@ func decodeHostMemoryCountersRecord(r io.Reader, length uint32) (HostMemoryCount
 		&c.SwapOut,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c HostMemoryCounters) Encode(w io.Writer) error {
@@ -698,13 +746,19 @@ func (c HostDiskCounters) RecordType() int {
 }
 
 func decodeHostDiskCountersRecord(r io.Reader, length uint32) (HostDiskCounters, error) {
-	c := HostDiskCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return HostDiskCounters{}, records.ErrDecodingRecord
 	}
 
+	c := HostDiskCounters{}
+	err := readHostDiskCountersRecord(b, &c)
+	return c, err
+}
+
+// readHostDiskCountersRecord decodes the record data held by b into c
+func readHostDiskCountersRecord(b []byte, c *HostDiskCounters) error {
 	fields := []interface{}{
 		&c.Total,
 		&c.Free,
@@ -717,7 +771,7 @@ func decodeHostDiskCountersRecord(r io.Reader, length uint32) (HostDiskCounters,
 		&c.WriteTime,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c HostDiskCounters) Encode(w io.Writer) error {
@@ -743,13 +797,19 @@ func (c HostNetCounters) RecordType() int {
 }
 
 func decodeHostNetCountersRecord(r io.Reader, length uint32) (HostNetCounters, error) {
-	c := HostNetCounters{}
 	b := make([]byte, int(length))
 	n, _ := r.Read(b)
 	if n != int(length) {
-		return c, records.ErrDecodingRecord
+		return HostNetCounters{}, records.ErrDecodingRecord
 	}
 
+	c := HostNetCounters{}
+	err := readHostNetCountersRecord(b, &c)
+	return c, err
+}
+
+// readHostNetCountersRecord decodes the record data held by b into c
+func readHostNetCountersRecord(b []byte, c *HostNetCounters) error {
 	fields := []interface{}{
 		&c.BytesIn,
 		&c.PacketsIn,
@@ -761,7 +821,7 @@ func decodeHostNetCountersRecord(r io.Reader, length uint32) (HostNetCounters, e
 		&c.DropsOut,
 	}
 
-	return c, readFields(b, fields)
+	return readFields(b, fields)
 }
 
 func (c HostNetCounters) Encode(w io.Writer) error {
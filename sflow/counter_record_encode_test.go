@@ -2,7 +2,11 @@ package sflow
 
 import (
 	"bytes"
+	"encoding/binary"
+	"io"
 	"testing"
+
+	"sflowbeat/sflow/records"
 )
 
 func TestEncodeDecodeGenericInterfaceCountersRecord(t *testing.T) {
@@ -103,3 +107,56 @@ func TestEncodeDecodeHostCPUCountersRecord(t *testing.T) {
 		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
 	}
 }
+
+// The records with 64 bit counters after 32 bit ones are written without the padding of their structs
+func TestEncodeDecodeUnpaddedCountersRecords(t *testing.T) {
+	for _, c := range []struct {
+		rec    records.Record
+		length uint32
+		decode func(io.Reader, uint32) (records.Record, error)
+	}{
+		{
+			VlanCounters{ID: 10, Octets: 1 << 40, UnicastPackets: 2, MulticastPackets: 3, BroadcastPackets: 4, Discards: 5},
+			28,
+			func(r io.Reader, length uint32) (records.Record, error) {
+				return decodeVlanCountersRecord(r, length)
+			},
+		},
+		{
+			ProcessorCounters{CPU5s: 1, CPU1m: 2, CPU5m: 3, TotalMemory: 1 << 40, FreeMemory: 1 << 20},
+			28,
+			func(r io.Reader, length uint32) (records.Record, error) {
+				return decodeProcessorCountersRecord(r, length)
+			},
+		},
+	} {
+		b := &bytes.Buffer{}
+
+		err := c.rec.Encode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var header struct {
+			RecordType uint32
+			Length     uint32
+		}
+		err = binary.Read(b, binary.BigEndian, &header)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if header.Length != c.length || uint32(b.Len()) != c.length {
+			t.Errorf("%T: expected the length %d, got %d with %d bytes of data", c.rec, c.length, header.Length, b.Len())
+		}
+
+		decoded, err := c.decode(b, header.Length)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if decoded != c.rec {
+			t.Errorf("expected\n%+#v\n, got\n%+#v", c.rec, decoded)
+		}
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"github.com/fstelzer/sflow/records"
 	"io"
+	"sflowbeat/sflow/records"
 )
 
 const (
@@ -27,11 +27,11 @@ const (
 )
 
 type CounterSample struct {
-	SequenceNum      uint32
-	SourceIdType     byte
-	SourceIdIndexVal uint32 // NOTE: this is 3 bytes in the datagram
+	SequenceNum      uint32 `json:"sequenceNum"`
+	SourceIdType     byte   `json:"sourceIdType"`
+	SourceIdIndexVal uint32 `json:"sourceIdIndexVal"` // NOTE: this is 3 bytes in the datagram
 	numRecords       uint32
-	Records          []records.Record
+	Records          []records.Record `json:"records"`
 }
 
 func (s CounterSample) String() string {
@@ -49,7 +49,7 @@ func (s *CounterSample) GetRecords() []records.Record {
 	return s.Records
 }
 
-func decodeCounterSample(r io.ReadSeeker) (Sample, error) {
+func decodeCounterSample(r io.ReadSeeker, rep *reporter) (Sample, error) {
 	s := &CounterSample{}
 
 	var err error
@@ -75,8 +75,8 @@ func decodeCounterSample(r io.ReadSeeker) (Sample, error) {
 	}
 
 	s.SourceIdIndexVal = uint32(srcIdIndexVal[2]) |
-		uint32(srcIdIndexVal[1]<<8) |
-		uint32(srcIdIndexVal[0]<<16)
+		uint32(srcIdIndexVal[1])<<8 |
+		uint32(srcIdIndexVal[0])<<16
 
 	err = binary.Read(r, binary.BigEndian, &s.numRecords)
 	if err != nil {
@@ -100,68 +100,21 @@ func decodeCounterSample(r io.ReadSeeker) (Sample, error) {
 				MaximumRecordLength, length)
 		}
 
-		var rec records.Record
-
-		switch format {
-		case TypeGenericInterfaceCountersRecord:
-			rec, err = decodeGenericInterfaceCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		case TypeEthernetCountersRecord:
-			rec, err = decodeEthernetCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		case TypeTokenRingCountersRecord:
-			rec, err = decodeTokenRingCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		case TypeVgCountersRecord:
-			rec, err = decodeVgCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		case TypeVlanCountersRecord:
-			rec, err = decodeVlanCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		case TypeProcessorCountersRecord:
-			rec, err = decodeProcessorCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		case TypeHostCPUCountersRecord:
-			rec, err = decodeHostCPUCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		case TypeHostMemoryCountersRecord:
-			rec, err = decodeHostMemoryCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		case TypeHostDiskCountersRecord:
-			rec, err = decodeHostDiskCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		case TypeHostNetCountersRecord:
-			rec, err = decodeHostNetCountersRecord(r, length)
-			if err != nil {
-				return nil, err
-			}
-		default:
-			if rec, err = records.DecodeCounter(r, format); err != nil {
-				fmt.Printf("Error: %s\n", err)
-				_, err := r.Seek(int64(length), 1)
-				if err != nil {
-					return nil, err
-				}
-				continue
-			}
+		rr, err := newBoundedReader(r, length)
+		if err != nil {
+			return nil, err
+		}
+
+		rec, err := decodeCounterRecord(rr, format, length)
+		err = rr.check(format, length, err)
+		rep.checkRecord(format, length, rr.start, rr.consumed(), rec, err)
+
+		// Continue with the next record in any case
+		if skipErr := rr.skip(); skipErr != nil {
+			return nil, skipErr
+		}
+		if err != nil {
+			continue
 		}
 
 		s.Records = append(s.Records, rec)
@@ -169,6 +122,39 @@ func decodeCounterSample(r io.ReadSeeker) (Sample, error) {
 	return s, nil
 }
 
+// decodeCounterRecord decodes a counter record of the given format and length from r
+func decodeCounterRecord(r io.Reader, format, length uint32) (records.Record, error) {
+	var rec records.Record
+	var err error
+
+	switch format {
+	case TypeGenericInterfaceCountersRecord:
+		rec, err = decodeGenericInterfaceCountersRecord(r, length)
+	case TypeEthernetCountersRecord:
+		rec, err = decodeEthernetCountersRecord(r, length)
+	case TypeTokenRingCountersRecord:
+		rec, err = decodeTokenRingCountersRecord(r, length)
+	case TypeVgCountersRecord:
+		rec, err = decodeVgCountersRecord(r, length)
+	case TypeVlanCountersRecord:
+		rec, err = decodeVlanCountersRecord(r, length)
+	case TypeProcessorCountersRecord:
+		rec, err = decodeProcessorCountersRecord(r, length)
+	case TypeHostCPUCountersRecord:
+		rec, err = decodeHostCPUCountersRecord(r, length)
+	case TypeHostMemoryCountersRecord:
+		rec, err = decodeHostMemoryCountersRecord(r, length)
+	case TypeHostDiskCountersRecord:
+		rec, err = decodeHostDiskCountersRecord(r, length)
+	case TypeHostNetCountersRecord:
+		rec, err = decodeHostNetCountersRecord(r, length)
+	default:
+		return records.DecodeCounter(r, format)
+	}
+
+	return rec, err
+}
+
 func (s *CounterSample) encode(w io.Writer) error {
 	var err error
 
@@ -201,7 +187,7 @@ func (s *CounterSample) encode(w io.Writer) error {
 		return err
 	}
 	err = binary.Write(w, binary.BigEndian,
-		uint32(s.SourceIdType)|s.SourceIdIndexVal<<24)
+		uint32(s.SourceIdType)<<24|s.SourceIdIndexVal&0xffffff)
 	if err != nil {
 		return err
 	}
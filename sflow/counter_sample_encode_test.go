@@ -3,6 +3,8 @@ package sflow
 import (
 	"bytes"
 	"os"
+	"reflect"
+	"sflowbeat/sflow/records"
 	"testing"
 )
 
@@ -48,7 +50,7 @@ func TestDecodeEncodeAndDecodeCounterSample(t *testing.T) {
 	buf.Read(skip[:])
 
 	// bytes.Buffer is not an io.ReadSeeker. bytes.Reader is.
-	decodedSample, err := decodeCounterSample(bytes.NewReader(buf.Bytes()))
+	decodedSample, err := decodeCounterSample(bytes.NewReader(buf.Bytes()), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -103,3 +105,65 @@ func TestDecodeEncodeAndDecodeCounterSample(t *testing.T) {
 		t.Errorf("expected\n%#v, got\n%#v", expectedGenericInterfaceCounters, genericInterfaceCounters)
 	}
 }
+
+func TestEncodeDecodeEnterpriseCounterSample(t *testing.T) {
+	sample := &CounterSample{
+		SequenceNum: 1,
+		Records: []records.Record{
+			GenericInterfaceCounters{Index: 1, Speed: 10000000000},
+			records.BroadcomDeviceBuffersCounter{UnicastPc: 1500, MulticastPc: 20},
+			records.BroadcomTablesCounter{ECMPNextHops: 5, ECMPNextHopsMax: 1024},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+
+	err := sample.encode(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// We need to skip the first 8 bytes. That's the header.
+	var skip [8]byte
+	buf.Read(skip[:])
+
+	decodedSample, err := decodeCounterSample(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := decodedSample.(*CounterSample)
+	if !reflect.DeepEqual(sample.Records, decoded.Records) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", sample.Records, decoded.Records)
+	}
+}
+
+func TestEncodeDecodeCounterSampleSourceID(t *testing.T) {
+	sample := &CounterSample{
+		SequenceNum:      1,
+		SourceIdType:     2,
+		SourceIdIndexVal: 0xfedcba,
+	}
+
+	buf := &bytes.Buffer{}
+
+	err := sample.encode(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// We need to skip the first 8 bytes. That's the header.
+	var skip [8]byte
+	buf.Read(skip[:])
+
+	decodedSample, err := decodeCounterSample(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := decodedSample.(*CounterSample)
+	if decoded.SourceIdType != sample.SourceIdType || decoded.SourceIdIndexVal != sample.SourceIdIndexVal {
+		t.Errorf("expected source id %d:%#x, got %d:%#x", sample.SourceIdType, sample.SourceIdIndexVal,
+			decoded.SourceIdType, decoded.SourceIdIndexVal)
+	}
+}
@@ -1,36 +1,62 @@
 package sflow
 
 import (
-	"os"
+	"bytes"
+	"io"
+	"io/ioutil"
 	"testing"
 )
 
-func BenchmarkFlow1Sample(b *testing.B) {
-	f, err := os.Open("_test/flow_sample.dump")
+func benchmarkDecode(b *testing.B, dump string) {
+	buf, err := ioutil.ReadFile(dump)
 	if err != nil {
 		b.Fatal(err)
 	}
 
-	d := NewDecoder(f)
+	r := bytes.NewReader(buf)
+	d := NewDecoder(r)
+
+	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		d.Decode()
+		r.Seek(0, io.SeekStart)
+		if _, err := d.Decode(); err != nil {
+			b.Fatal(err)
+		}
 	}
-
-	f.Close()
 }
 
-func BenchmarkCounterSample(b *testing.B) {
-	f, err := os.Open("_test/counter_sample.dump")
+func benchmarkDecodeBytes(b *testing.B, dump string) {
+	buf, err := ioutil.ReadFile(dump)
 	if err != nil {
 		b.Fatal(err)
 	}
 
-	d := NewDecoder(f)
+	d := NewDecoder(nil)
+
+	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		d.Decode()
+		dgram, err := d.DecodeBytes(buf)
+		if err != nil {
+			b.Fatal(err)
+		}
+		dgram.Release()
 	}
+}
+
+func BenchmarkFlow1Sample(b *testing.B) {
+	benchmarkDecode(b, "_test/flow_sample.dump")
+}
+
+func BenchmarkCounterSample(b *testing.B) {
+	benchmarkDecode(b, "_test/counter_sample.dump")
+}
+
+func BenchmarkFlow1SampleBytes(b *testing.B) {
+	benchmarkDecodeBytes(b, "_test/flow_sample.dump")
+}
 
-	f.Close()
+func BenchmarkCounterSampleBytes(b *testing.B) {
+	benchmarkDecodeBytes(b, "_test/counter_sample.dump")
 }
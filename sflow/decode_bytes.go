@@ -0,0 +1,466 @@
+package sflow
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"sflowbeat/sflow/records"
+)
+
+// Datagrams, samples and the counter records of this package decoded by DecodeBytes are taken from
+// these pools and put back by Release. The records package pools the other records.
+var (
+	datagramPool      = sync.Pool{New: func() interface{} { return &Datagram{} }}
+	flowSamplePool    = sync.Pool{New: func() interface{} { return &FlowSample{} }}
+	counterSamplePool = sync.Pool{New: func() interface{} { return &CounterSample{} }}
+
+	genericInterfaceCountersPool = sync.Pool{New: func() interface{} { return &GenericInterfaceCounters{} }}
+	ethernetCountersPool         = sync.Pool{New: func() interface{} { return &EthernetCounters{} }}
+	tokenRingCountersPool        = sync.Pool{New: func() interface{} { return &TokenRingCounters{} }}
+	vgCountersPool               = sync.Pool{New: func() interface{} { return &VgCounters{} }}
+	vlanCountersPool             = sync.Pool{New: func() interface{} { return &VlanCounters{} }}
+	processorCountersPool        = sync.Pool{New: func() interface{} { return &ProcessorCounters{} }}
+	hostCPUCountersPool          = sync.Pool{New: func() interface{} { return &HostCPUCounters{} }}
+	hostMemoryCountersPool       = sync.Pool{New: func() interface{} { return &HostMemoryCounters{} }}
+	hostDiskCountersPool         = sync.Pool{New: func() interface{} { return &HostDiskCounters{} }}
+	hostNetCountersPool          = sync.Pool{New: func() interface{} { return &HostNetCounters{} }}
+)
+
+// xdrBuffer reads XDR encoded values from a datagram held in memory.
+// base is the offset of b within the datagram.
+type xdrBuffer struct {
+	b    []byte
+	off  int
+	base int
+}
+
+func (x *xdrBuffer) uint32() (uint32, error) {
+	if len(x.b)-x.off < 4 {
+		return 0, x.eof()
+	}
+
+	v := binary.BigEndian.Uint32(x.b[x.off:])
+	x.off += 4
+	return v, nil
+}
+
+func (x *xdrBuffer) bytes(n int) ([]byte, error) {
+	if len(x.b)-x.off < n {
+		return nil, x.eof()
+	}
+
+	b := x.b[x.off : x.off+n : x.off+n]
+	x.off += n
+	return b, nil
+}
+
+// eof returns the error of binary.Read for the rest of the buffer
+func (x *xdrBuffer) eof() error {
+	if x.off == len(x.b) {
+		return io.EOF
+	}
+	x.off = len(x.b)
+	return io.ErrUnexpectedEOF
+}
+
+// next returns a buffer holding the next length bytes, or less if the buffer ends before, and skips them
+func (x *xdrBuffer) next(length uint32) xdrBuffer {
+	end := len(x.b)
+	if remaining := end - x.off; int64(length) < int64(remaining) {
+		end = x.off + int(length)
+	}
+
+	next := xdrBuffer{b: x.b[x.off:end:end], base: x.base + x.off}
+	x.off = end
+	return next
+}
+
+// DecodeBytes decodes a datagram held by b, like Decode does from the reader of the Decoder.
+// The datagram is parsed in place: byte slices of the records (like RawPacketFlow.Header and the
+// addresses) refer to b, which must not be modified while the datagram is in use. The datagram, its
+// samples and their records are taken from pools, they can be handed back with Release once they are
+// no longer used. Unlike Decode, the records are pointers, like *records.RawPacketFlow.
+// Decoding datagrams of the common records and packet headers does not allocate once the pools are filled,
+// and neither does skipping records of unknown types unless they are reported to the Diagnostics.
+func (d *Decoder) DecodeBytes(b []byte) (*Datagram, error) {
+	x := &xdrBuffer{b: b}
+	rep := &reporter{diagnostics: d.diagnostics}
+
+	dgram := datagramPool.Get().(*Datagram)
+	*dgram = Datagram{Samples: dgram.Samples[:0]}
+
+	if err := d.decodeDatagramBytes(x, rep, dgram); err != nil {
+		dgram.Release()
+		return nil, err
+	}
+
+	if len(dgram.Samples) == 0 {
+		dgram.Samples = nil
+	}
+
+	return dgram, nil
+}
+
+func (d *Decoder) decodeDatagramBytes(x *xdrBuffer, rep *reporter, dgram *Datagram) error {
+	var err error
+
+	if dgram.Version, err = x.uint32(); err != nil {
+		return err
+	}
+
+	if dgram.Version != 5 {
+		return ErrUnsupportedDatagramVersion
+	}
+
+	if dgram.IpVersion, err = x.uint32(); err != nil {
+		return err
+	}
+
+	ipLen := 4
+	if dgram.IpVersion == 2 {
+		ipLen = 16
+	}
+
+	if dgram.IpAddress, err = x.bytes(ipLen); err != nil {
+		return err
+	}
+
+	if dgram.SubAgentId, err = x.uint32(); err != nil {
+		return err
+	}
+
+	if dgram.SequenceNumber, err = x.uint32(); err != nil {
+		return err
+	}
+
+	if dgram.Uptime, err = x.uint32(); err != nil {
+		return err
+	}
+
+	if dgram.NumSamples, err = x.uint32(); err != nil {
+		return err
+	}
+
+	rep.agent = dgram.IpAddress
+	rep.sequenceNumber = dgram.SequenceNumber
+
+	for i := dgram.NumSamples; i > 0; i-- {
+		sample, err := decodeSampleBytes(x, rep)
+		if err != nil {
+			return err
+		}
+
+		// Samples which failed to decode are skipped and reported to the Diagnostics
+		if sample == nil {
+			continue
+		}
+
+		dgram.Samples = append(dgram.Samples, sample)
+	}
+
+	return nil
+}
+
+// decodeSampleBytes decodes the next sample of a datagram like decodeSample
+func decodeSampleBytes(x *xdrBuffer, rep *reporter) (Sample, error) {
+	format, err := x.uint32()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := x.uint32()
+	if err != nil {
+		return nil, err
+	}
+
+	rep.sampleType = format
+
+	sx := x.next(length)
+
+	var sample Sample
+
+	switch format {
+	case TypeCounterSample:
+		sample, err = decodeCounterSampleBytes(&sx, rep)
+	case TypeFlowSample:
+		sample, err = decodeFlowSampleBytes(&sx, rep)
+	default:
+		err = ErrUnknownSampleType
+	}
+
+	if err != ErrUnknownSampleType {
+		err = checkLength(format, length, int64(length), int64(sx.off), err)
+	}
+	rep.checkSample(length, int64(sx.base), int64(sx.off), err)
+
+	if err != nil {
+		releaseSample(sample)
+		return nil, nil
+	}
+
+	return sample, nil
+}
+
+// decodeSourceID decodes the source id type and the 3 bytes source id index of a sample
+func decodeSourceID(x *xdrBuffer) (byte, uint32, error) {
+	b, err := x.bytes(4)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return b[0], uint32(b[3]) | uint32(b[2])<<8 | uint32(b[1])<<16, nil
+}
+
+func decodeFlowSampleBytes(x *xdrBuffer, rep *reporter) (*FlowSample, error) {
+	s := flowSamplePool.Get().(*FlowSample)
+	*s = FlowSample{Records: s.Records[:0]}
+
+	if err := decodeFlowSampleFieldsBytes(x, s); err != nil {
+		return s, err
+	}
+
+	for i := uint32(0); i < s.numRecords; i++ {
+		format, err := x.uint32()
+		if err != nil {
+			return s, err
+		}
+
+		length, err := x.uint32()
+		if err != nil {
+			return s, err
+		}
+
+		if length > MaximumRecordLength {
+			return s, fmt.Errorf("sflow: record length more than %d: %d",
+				MaximumRecordLength, length)
+		}
+
+		rx := x.next(length)
+
+		rec, consumed, err := records.DecodeFlowBytes(rx.b, format)
+		if err == records.ErrUnknownRecordType {
+			err = rep.unknownRecord("flow", format)
+		}
+		err = checkLength(format, length, int64(len(rx.b)), int64(consumed), err)
+		rep.checkRecord(format, length, int64(rx.base), int64(consumed), rec, err)
+
+		if err != nil {
+			releaseRecord(rec)
+			continue
+		}
+
+		s.Records = append(s.Records, rec)
+	}
+
+	if len(s.Records) == 0 {
+		s.Records = nil
+	}
+
+	return s, nil
+}
+
+func decodeFlowSampleFieldsBytes(x *xdrBuffer, s *FlowSample) error {
+	var err error
+
+	if s.SequenceNum, err = x.uint32(); err != nil {
+		return err
+	}
+
+	if s.SourceIdType, s.SourceIdIndexVal, err = decodeSourceID(x); err != nil {
+		return err
+	}
+
+	fields := []*uint32{&s.SamplingRate, &s.SamplePool, &s.Drops, &s.Input, &s.Output, &s.numRecords}
+	for _, field := range fields {
+		if *field, err = x.uint32(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodeCounterSampleBytes(x *xdrBuffer, rep *reporter) (*CounterSample, error) {
+	s := counterSamplePool.Get().(*CounterSample)
+	*s = CounterSample{Records: s.Records[:0]}
+
+	var err error
+
+	if s.SequenceNum, err = x.uint32(); err != nil {
+		return s, err
+	}
+
+	if s.SourceIdType, s.SourceIdIndexVal, err = decodeSourceID(x); err != nil {
+		return s, err
+	}
+
+	if s.numRecords, err = x.uint32(); err != nil {
+		return s, err
+	}
+
+	for i := uint32(0); i < s.numRecords; i++ {
+		format, err := x.uint32()
+		if err != nil {
+			return s, err
+		}
+
+		length, err := x.uint32()
+		if err != nil {
+			return s, err
+		}
+
+		if length > MaximumRecordLength {
+			return s, fmt.Errorf("sflow: record length more than %d: %d",
+				MaximumRecordLength, length)
+		}
+
+		rx := x.next(length)
+
+		rec, consumed, err := decodeCounterRecordBytes(rx.b, format, length)
+		if err == records.ErrUnknownRecordType {
+			err = rep.unknownRecord("counter", format)
+		}
+		err = checkLength(format, length, int64(len(rx.b)), int64(consumed), err)
+		rep.checkRecord(format, length, int64(rx.base), int64(consumed), rec, err)
+
+		if err != nil {
+			releaseRecord(rec)
+			continue
+		}
+
+		s.Records = append(s.Records, rec)
+	}
+
+	if len(s.Records) == 0 {
+		s.Records = nil
+	}
+
+	return s, nil
+}
+
+// decodeCounterRecordBytes decodes a counter record of the given format and length from b like decodeCounterRecord
+func decodeCounterRecordBytes(b []byte, format, length uint32) (records.Record, int, error) {
+	switch format {
+	case TypeGenericInterfaceCountersRecord, TypeEthernetCountersRecord, TypeTokenRingCountersRecord,
+		TypeVgCountersRecord, TypeVlanCountersRecord, TypeProcessorCountersRecord, TypeHostCPUCountersRecord,
+		TypeHostMemoryCountersRecord, TypeHostDiskCountersRecord, TypeHostNetCountersRecord:
+		if len(b) != int(length) {
+			return nil, len(b), records.ErrDecodingRecord
+		}
+	default:
+		return records.DecodeCounterBytes(b, format)
+	}
+
+	var rec records.Record
+	var err error
+
+	switch format {
+	case TypeGenericInterfaceCountersRecord:
+		c := genericInterfaceCountersPool.Get().(*GenericInterfaceCounters)
+		*c = GenericInterfaceCounters{}
+		rec, err = c, readGenericInterfaceCountersRecord(b, c)
+	case TypeEthernetCountersRecord:
+		c := ethernetCountersPool.Get().(*EthernetCounters)
+		*c = EthernetCounters{}
+		rec, err = c, readEthernetCountersRecord(b, c)
+	case TypeTokenRingCountersRecord:
+		c := tokenRingCountersPool.Get().(*TokenRingCounters)
+		*c = TokenRingCounters{}
+		rec, err = c, readTokenRingCountersRecord(b, c)
+	case TypeVgCountersRecord:
+		c := vgCountersPool.Get().(*VgCounters)
+		*c = VgCounters{}
+		rec, err = c, readVgCountersRecord(b, c)
+	case TypeVlanCountersRecord:
+		c := vlanCountersPool.Get().(*VlanCounters)
+		*c = VlanCounters{}
+		rec, err = c, readVlanCountersRecord(b, c)
+	case TypeProcessorCountersRecord:
+		c := processorCountersPool.Get().(*ProcessorCounters)
+		*c = ProcessorCounters{}
+		rec, err = c, readProcessorCountersRecord(b, c)
+	case TypeHostCPUCountersRecord:
+		c := hostCPUCountersPool.Get().(*HostCPUCounters)
+		*c = HostCPUCounters{}
+		rec, err = c, readHostCPUCountersRecord(b, c)
+	case TypeHostMemoryCountersRecord:
+		c := hostMemoryCountersPool.Get().(*HostMemoryCounters)
+		*c = HostMemoryCounters{}
+		rec, err = c, readHostMemoryCountersRecord(b, c)
+	case TypeHostDiskCountersRecord:
+		c := hostDiskCountersPool.Get().(*HostDiskCounters)
+		*c = HostDiskCounters{}
+		rec, err = c, readHostDiskCountersRecord(b, c)
+	case TypeHostNetCountersRecord:
+		c := hostNetCountersPool.Get().(*HostNetCounters)
+		*c = HostNetCounters{}
+		rec, err = c, readHostNetCountersRecord(b, c)
+	}
+
+	return rec, len(b), err
+}
+
+// Release hands the datagram, its samples and their records back to the pools of DecodeBytes.
+// Neither the datagram nor its samples and records may be used afterwards.
+func (dgram *Datagram) Release() {
+	for i, sample := range dgram.Samples {
+		releaseSample(sample)
+		dgram.Samples[i] = nil
+	}
+
+	*dgram = Datagram{Samples: dgram.Samples[:0]}
+	datagramPool.Put(dgram)
+}
+
+func releaseSample(sample Sample) {
+	switch s := sample.(type) {
+	case *FlowSample:
+		if s == nil {
+			return
+		}
+		for i, rec := range s.Records {
+			releaseRecord(rec)
+			s.Records[i] = nil
+		}
+		flowSamplePool.Put(s)
+	case *CounterSample:
+		if s == nil {
+			return
+		}
+		for i, rec := range s.Records {
+			releaseRecord(rec)
+			s.Records[i] = nil
+		}
+		counterSamplePool.Put(s)
+	}
+}
+
+// releaseRecord hands a record decoded by DecodeBytes back to its pool
+func releaseRecord(rec records.Record) {
+	switch c := rec.(type) {
+	case *GenericInterfaceCounters:
+		genericInterfaceCountersPool.Put(c)
+	case *EthernetCounters:
+		ethernetCountersPool.Put(c)
+	case *TokenRingCounters:
+		tokenRingCountersPool.Put(c)
+	case *VgCounters:
+		vgCountersPool.Put(c)
+	case *VlanCounters:
+		vlanCountersPool.Put(c)
+	case *ProcessorCounters:
+		processorCountersPool.Put(c)
+	case *HostCPUCounters:
+		hostCPUCountersPool.Put(c)
+	case *HostMemoryCounters:
+		hostMemoryCountersPool.Put(c)
+	case *HostDiskCounters:
+		hostDiskCountersPool.Put(c)
+	case *HostNetCounters:
+		hostNetCountersPool.Put(c)
+	default:
+		records.ReleaseRecord(rec)
+	}
+}
@@ -0,0 +1,179 @@
+package sflow
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"reflect"
+	"testing"
+
+	"sflowbeat/sflow/records"
+)
+
+var testDumps = []string{
+	"_test/counter_sample.dump",
+	"_test/flow_sample.dump",
+	"_test/flow_sample_3.dump",
+	"_test/flow_samples_2.dump",
+	"_test/host_sample.dump",
+}
+
+// testRecordValues returns a copy of a datagram decoded by DecodeBytes which holds the records as values, like Decode
+func testRecordValues(dgram *Datagram) *Datagram {
+	if dgram == nil {
+		return nil
+	}
+
+	values := func(recs []records.Record) []records.Record {
+		if recs == nil {
+			return nil
+		}
+
+		copied := make([]records.Record, len(recs))
+		for i, rec := range recs {
+			if v := reflect.ValueOf(rec); v.Kind() == reflect.Ptr {
+				rec = v.Elem().Interface().(records.Record)
+			}
+			copied[i] = rec
+		}
+		return copied
+	}
+
+	copied := *dgram
+	copied.Samples = make([]Sample, len(dgram.Samples))
+	for i, sample := range dgram.Samples {
+		switch s := sample.(type) {
+		case *FlowSample:
+			c := *s
+			c.Records = values(s.Records)
+			sample = &c
+		case *CounterSample:
+			c := *s
+			c.Records = values(s.Records)
+			sample = &c
+		}
+		copied.Samples[i] = sample
+	}
+	if dgram.Samples == nil {
+		copied.Samples = nil
+	}
+
+	return &copied
+}
+
+func TestDecodeBytesMatchesDecode(t *testing.T) {
+	for _, dump := range testDumps {
+		b, err := ioutil.ReadFile(dump)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := NewDecoder(bytes.NewReader(b)).Decode()
+		if err != nil {
+			t.Fatalf("%s: %v", dump, err)
+		}
+
+		dgram, err := NewDecoder(nil).DecodeBytes(b)
+		if err != nil {
+			t.Fatalf("%s: %v", dump, err)
+		}
+
+		if !reflect.DeepEqual(testRecordValues(dgram), expected) {
+			t.Errorf("%s: expected\n%+#v\n, got\n%+#v", dump, expected, dgram)
+		}
+
+		dgram.Release()
+	}
+}
+
+func TestDecodeBytesAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops values at random under the race detector")
+	}
+
+	for _, dump := range testDumps {
+		b, err := ioutil.ReadFile(dump)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		d := NewDecoder(nil)
+		allocs := testing.AllocsPerRun(100, func() {
+			dgram, err := d.DecodeBytes(b)
+			if err != nil {
+				t.Fatalf("%s: %v", dump, err)
+			}
+			dgram.Release()
+		})
+
+		if allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %v per datagram", dump, allocs)
+		}
+	}
+}
+
+func TestDecodeBytesDiagnostics(t *testing.T) {
+	samples := []Sample{
+		&testRawSample{sampleType: TypeExpandedFlowSample, data: make([]byte, 12)},
+		&FlowSample{SequenceNum: 2, Records: []records.Record{
+			testSwitchRecord(10, 20, 0xde, 0xad, 0xbe, 0xef),
+			testRawRecord{recordType: records.TypeExtendedSwitchFlowRecord, length: 12, data: make([]byte, 12)},
+			testSwitchRecord(30, 16),
+			testRawRecord{recordType: 9999, length: 4, data: make([]byte, 4)},
+		}},
+		&CounterSample{SequenceNum: 3, Records: []records.Record{
+			testRawRecord{recordType: TypeEthernetCountersRecord, length: 8, data: make([]byte, 8)},
+			testRawRecord{recordType: 9999, length: 4, data: make([]byte, 4)},
+		}},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(net.ParseIP("192.0.2.1"), 0, 1)
+	if err := enc.Encode(buf, samples); err != nil {
+		t.Fatal(err)
+	}
+
+	decode := func(decode func(d *Decoder) (*Datagram, error)) (*Datagram, []Diagnostic) {
+		var diagnostics []Diagnostic
+
+		d := NewDecoder(bytes.NewReader(buf.Bytes()))
+		d.SetDiagnostics(DiagnosticsFunc(func(diagnostic Diagnostic) {
+			diagnostics = append(diagnostics, diagnostic)
+		}))
+
+		dgram, err := decode(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return dgram, diagnostics
+	}
+
+	expected, expectedDiagnostics := decode(func(d *Decoder) (*Datagram, error) { return d.Decode() })
+	dgram, diagnostics := decode(func(d *Decoder) (*Datagram, error) { return d.DecodeBytes(buf.Bytes()) })
+
+	if !reflect.DeepEqual(testRecordValues(dgram), expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, dgram)
+	}
+
+	if len(expectedDiagnostics) != 5 {
+		t.Fatalf("expected 5 diagnostics, got %v", expectedDiagnostics)
+	}
+
+	if !reflect.DeepEqual(diagnostics, expectedDiagnostics) {
+		t.Errorf("expected\n%v\n, got\n%v", expectedDiagnostics, diagnostics)
+	}
+}
+
+func TestDecodeBytesTruncated(t *testing.T) {
+	b, err := ioutil.ReadFile("_test/flow_sample.dump")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []int{0, 10, 27} {
+		if _, err := NewDecoder(nil).DecodeBytes(b[:n]); err == nil {
+			t.Errorf("expected an error decoding %d bytes", n)
+		}
+	}
+}
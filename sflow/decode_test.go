@@ -1,8 +1,10 @@
 package sflow
 
 import (
-	"github.com/fstelzer/sflow/records"
+	"bytes"
+	"encoding/binary"
 	"os"
+	"sflowbeat/sflow/records"
 	"testing"
 )
 
@@ -174,3 +176,37 @@ func TestDecodeFlow1(t *testing.T) {
 		t.Errorf("expected FrameLength to be 128, got %d", rec.HeaderSize)
 	}
 }
+
+func TestDecodeSourceIDIndex(t *testing.T) {
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.BigEndian, []uint32{5, 1, 0xc0000201, 0, 1, 1000, 2})
+	// Flow sample without records, source id type 1 and index 0x012345
+	binary.Write(buf, binary.BigEndian, []uint32{TypeFlowSample, 32, 1, 0x01012345, 256, 1024, 0, 1, 2, 0})
+	// Counter sample without records, source id type 2 and index 0xfedcba
+	binary.Write(buf, binary.BigEndian, []uint32{TypeCounterSample, 12, 2, 0x02fedcba, 0})
+
+	decoded, err := NewDecoder(bytes.NewReader(buf.Bytes())).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedBytes, err := NewDecoder(nil).DecodeBytes(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dgram := range []*Datagram{decoded, decodedBytes} {
+		if len(dgram.Samples) != 2 {
+			t.Fatalf("expected 2 samples, got %d", len(dgram.Samples))
+		}
+
+		flow := dgram.Samples[0].(*FlowSample)
+		if flow.SourceIdType != 1 || flow.SourceIdIndexVal != 0x012345 {
+			t.Errorf("expected the flow source id 1:%d, got %d:%d", 0x012345, flow.SourceIdType, flow.SourceIdIndexVal)
+		}
+
+		counter := dgram.Samples[1].(*CounterSample)
+		if counter.SourceIdType != 2 || counter.SourceIdIndexVal != 0xfedcba {
+			t.Errorf("expected the counter source id 2:%d, got %d:%d", 0xfedcba, counter.SourceIdType, counter.SourceIdIndexVal)
+		}
+	}
+}
@@ -23,7 +23,8 @@ const (
 var ErrUnsupportedDatagramVersion = errors.New("sflow: unsupported datagram version")
 
 type Decoder struct {
-	reader io.ReadSeeker
+	reader      io.ReadSeeker
+	diagnostics Diagnostics
 }
 
 func NewDecoder(r io.ReadSeeker) *Decoder {
@@ -36,11 +37,22 @@ func (d *Decoder) Use(r io.ReadSeeker) {
 	d.reader = r
 }
 
+// SetDiagnostics sets the receiver of the Diagnostic events for data which could not be (fully) decoded.
+// Diagnostics are discarded if it is nil.
+func (d *Decoder) SetDiagnostics(diagnostics Diagnostics) {
+	d.diagnostics = diagnostics
+}
+
 func (d *Decoder) Decode() (*Datagram, error) {
 	// Decode headers first
 	dgram := &Datagram{}
 	var err error
 
+	rep := &reporter{diagnostics: d.diagnostics}
+	if d.diagnostics != nil {
+		rep.base = offset(d.reader)
+	}
+
 	err = binary.Read(d.reader, binary.BigEndian, &dgram.Version)
 	if err != nil {
 		return nil, err
@@ -88,12 +100,20 @@ func (d *Decoder) Decode() (*Datagram, error) {
 		return nil, err
 	}
 
+	rep.agent = dgram.IpAddress
+	rep.sequenceNumber = dgram.SequenceNumber
+
 	for i := dgram.NumSamples; i > 0; i-- {
-		sample, err := decodeSample(d.reader)
+		sample, err := decodeSample(d.reader, rep)
 		if err != nil {
 			return nil, err
 		}
 
+		// Samples which failed to decode are skipped and reported to the Diagnostics
+		if sample == nil {
+			continue
+		}
+
 		dgram.Samples = append(dgram.Samples, sample)
 	}
 
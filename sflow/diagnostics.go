@@ -0,0 +1,208 @@
+package sflow
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"sflowbeat/sflow/records"
+)
+
+// DiagnosticKind is the type of a Diagnostic
+type DiagnosticKind int
+
+const (
+	// DiagnosticUnknownRecord reports a flow or counter record without a decoder, which was skipped
+	DiagnosticUnknownRecord DiagnosticKind = iota + 1
+	// DiagnosticRecordError reports a record which failed to decode and was skipped
+	DiagnosticRecordError
+	// DiagnosticLengthMismatch reports a sample or record whose decoder consumed more or less than its
+	// announced length, which was skipped. Err is a *LengthError.
+	DiagnosticLengthMismatch
+	// DiagnosticTruncatedHeader reports a sampled packet header which ended within one of its headers
+	DiagnosticTruncatedHeader
+	// DiagnosticUnknownProtocol reports a sampled packet header containing a protocol without a dissector
+	DiagnosticUnknownProtocol
+	// DiagnosticUnknownSample reports a sample without a decoder, which was skipped
+	DiagnosticUnknownSample
+	// DiagnosticSampleError reports a sample which failed to decode and was skipped
+	DiagnosticSampleError
+)
+
+var diagnosticKindNames = map[DiagnosticKind]string{
+	DiagnosticUnknownRecord:   "unknown_record",
+	DiagnosticRecordError:     "record_error",
+	DiagnosticLengthMismatch:  "length_mismatch",
+	DiagnosticTruncatedHeader: "truncated_header",
+	DiagnosticUnknownProtocol: "unknown_protocol",
+	DiagnosticUnknownSample:   "unknown_sample",
+	DiagnosticSampleError:     "sample_error",
+}
+
+func (k DiagnosticKind) String() string {
+	if name, found := diagnosticKindNames[k]; found {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", int(k))
+}
+
+// Diagnostic is an event reported by a Decoder for data it could not (fully) decode.
+// Offsets are relative to the start of the datagram.
+type Diagnostic struct {
+	Kind           DiagnosticKind
+	Agent          net.IP
+	SequenceNumber uint32 /* sequence number of the datagram */
+	SampleType     uint32
+	RecordType     uint32 /* zero for diagnostics of samples */
+	Offset         int64  /* offset of the sample or record data */
+	Length         uint32 /* record length announced by the agent, or the sampled header length */
+	Consumed       int64  /* bytes consumed by the decoder */
+	Protocol       *records.UnknownProtocol
+	Err            error
+}
+
+func (d Diagnostic) String() string {
+	s := fmt.Sprintf("sflow: %s from agent %s (sequence %d): sample type %d, record type %d at offset %d, length %d, consumed %d",
+		d.Kind, d.Agent, d.SequenceNumber, d.SampleType, d.RecordType, d.Offset, d.Length, d.Consumed)
+
+	if d.Protocol != nil {
+		s += fmt.Sprintf(", %s %d", d.Protocol.Layer, d.Protocol.Protocol)
+	}
+	if d.Err != nil {
+		s += fmt.Sprintf(": %s", d.Err)
+	}
+
+	return s
+}
+
+// Diagnostics receives the Diagnostic events of a Decoder
+type Diagnostics interface {
+	Diagnostic(d Diagnostic)
+}
+
+// DiagnosticsFunc is an adapter to use a function as Diagnostics
+type DiagnosticsFunc func(d Diagnostic)
+
+// Diagnostic calls f(d)
+func (f DiagnosticsFunc) Diagnostic(d Diagnostic) {
+	f(d)
+}
+
+// offset returns the current offset of r
+func offset(r io.Seeker) int64 {
+	o, _ := r.Seek(0, io.SeekCurrent)
+	return o
+}
+
+// reporter reports diagnostics of the samples of a single datagram
+type reporter struct {
+	diagnostics    Diagnostics
+	agent          net.IP
+	sequenceNumber uint32
+	base           int64 /* offset of the datagram in the reader */
+	sampleType     uint32
+}
+
+func (r *reporter) report(d Diagnostic) {
+	if r == nil || r.diagnostics == nil {
+		return
+	}
+
+	d.Agent = r.agent
+	d.SequenceNumber = r.sequenceNumber
+	d.SampleType = r.sampleType
+	d.Offset -= r.base
+
+	r.diagnostics.Diagnostic(d)
+}
+
+// checkSample reports the error of decoding the current sample of the given announced length,
+// which started at offset start and of which consumed bytes were decoded
+func (r *reporter) checkSample(length uint32, start, consumed int64, err error) {
+	if r == nil || r.diagnostics == nil || err == nil {
+		return
+	}
+
+	d := Diagnostic{Offset: start, Length: length, Consumed: consumed, Err: err}
+
+	var lengthErr *LengthError
+	switch {
+	case err == ErrUnknownSampleType:
+		d.Kind = DiagnosticUnknownSample
+	case errors.As(err, &lengthErr):
+		d.Kind = DiagnosticLengthMismatch
+	default:
+		d.Kind = DiagnosticSampleError
+	}
+
+	r.report(d)
+}
+
+// unknownRecord returns the error Decode reports for a record of the given kind and unknown type.
+// DecodeBytes only wraps records.ErrUnknownRecordType when there are diagnostics to report it to.
+func (r *reporter) unknownRecord(kind string, recordType uint32) error {
+	if r == nil || r.diagnostics == nil {
+		return records.ErrUnknownRecordType
+	}
+
+	return fmt.Errorf("%s record type %d: %w", kind, recordType, records.ErrUnknownRecordType)
+}
+
+// checkRecord reports the outcome of decoding a record of the given type and announced length,
+// which started at offset start and of which consumed bytes were decoded
+func (r *reporter) checkRecord(recordType, length uint32, start, consumed int64, rec records.Record, err error) {
+	if r == nil || r.diagnostics == nil {
+		return
+	}
+
+	d := Diagnostic{RecordType: recordType, Offset: start, Length: length, Consumed: consumed, Err: err}
+
+	var lengthErr *LengthError
+	switch {
+	case errors.Is(err, records.ErrUnknownRecordType):
+		d.Kind = DiagnosticUnknownRecord
+	case errors.As(err, &lengthErr):
+		d.Kind = DiagnosticLengthMismatch
+	case err != nil:
+		d.Kind = DiagnosticRecordError
+	}
+
+	if err != nil {
+		r.report(d)
+		return
+	}
+
+	// Decode returns raw packet records as values, DecodeBytes as pointers
+	var raw *records.RawPacketFlow
+	switch f := rec.(type) {
+	case records.RawPacketFlow:
+		raw = &f
+	case *records.RawPacketFlow:
+		raw = f
+	}
+	if raw == nil || raw.DecodedHeader == nil {
+		return
+	}
+
+	// Diagnostics of the sampled packet header
+	header := Diagnostic{RecordType: recordType, Offset: start, Length: uint32(len(raw.Header)), Consumed: int64(raw.DecodedHeader.Offset)}
+
+	if len(raw.DecodedHeader.Errors) > 0 {
+		d := header
+		d.Kind = DiagnosticTruncatedHeader
+		d.Err = errors.New(strings.Join(raw.DecodedHeader.Errors, "; "))
+		r.report(d)
+	}
+
+	// Decoding of tunneled packets may stop at an unknown protocol as well
+	for p := raw.DecodedHeader; p != nil; p = p.Inner {
+		if p.Unknown != nil {
+			d := header
+			d.Kind = DiagnosticUnknownProtocol
+			d.Protocol = p.Unknown
+			r.report(d)
+		}
+	}
+}
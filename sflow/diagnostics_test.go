@@ -0,0 +1,80 @@
+package sflow
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"sflowbeat/sflow/records"
+)
+
+func TestDecoderDiagnostics(t *testing.T) {
+	truncated := []byte{
+		// IPv4 192.0.2.1 -> 198.51.100.7, UDP, cut short within the addresses
+		0x45, 0x00, 0x00, 0x1c, 0x00, 0x01, 0x00, 0x00, 0x40, 0x11, 0x00, 0x00,
+		192, 0, 2, 1,
+	}
+	unknown := []byte{
+		// Ethernet, EtherType 0x88b5 (local experimental)
+		0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0x88, 0xb5,
+		0x00, 0x00,
+	}
+
+	samples := []Sample{
+		&FlowSample{SequenceNum: 1, Records: []records.Record{
+			// A record type without a decoder
+			testRawRecord{recordType: 9999, length: 4, data: []byte{0xde, 0xad, 0xbe, 0xef}},
+			records.RawPacketFlow{Protocol: records.HeaderProtocolIPv4, FrameLength: 28, HeaderSize: uint32(len(truncated)), Header: truncated},
+			records.RawPacketFlow{Protocol: records.HeaderProtocolEthernetISO8023, FrameLength: 64, HeaderSize: uint32(len(unknown)), Header: unknown},
+		}},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(net.ParseIP("192.0.2.1"), 0, 7)
+	if err := enc.Encode(buf, samples); err != nil {
+		t.Fatal(err)
+	}
+
+	var diagnostics []Diagnostic
+
+	d := NewDecoder(bytes.NewReader(buf.Bytes()))
+	d.SetDiagnostics(DiagnosticsFunc(func(diagnostic Diagnostic) {
+		diagnostics = append(diagnostics, diagnostic)
+	}))
+
+	dgram, err := d.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(dgram.Samples[0].GetRecords()); n != 2 {
+		t.Errorf("expected the unknown record to be skipped, got %d records", n)
+	}
+
+	if len(diagnostics) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %v", len(diagnostics), diagnostics)
+	}
+
+	// Datagram header (28 bytes), sample header (8 bytes), flow sample fields (32 bytes) and record header (8 bytes)
+	unknownRecord := diagnostics[0]
+	if unknownRecord.Kind != DiagnosticUnknownRecord || unknownRecord.RecordType != 9999 ||
+		unknownRecord.Offset != 76 || unknownRecord.Length != 4 {
+		t.Errorf("unexpected diagnostic %s", unknownRecord)
+	}
+	if !unknownRecord.Agent.Equal(net.IPv4(192, 0, 2, 1)) || unknownRecord.SequenceNumber != 7 ||
+		unknownRecord.SampleType != TypeFlowSample {
+		t.Errorf("unexpected datagram of diagnostic %s", unknownRecord)
+	}
+
+	truncatedHeader := diagnostics[1]
+	if truncatedHeader.Kind != DiagnosticTruncatedHeader || truncatedHeader.Err == nil ||
+		truncatedHeader.Length != uint32(len(truncated)) {
+		t.Errorf("unexpected diagnostic %s", truncatedHeader)
+	}
+
+	unknownProtocol := diagnostics[2]
+	if unknownProtocol.Kind != DiagnosticUnknownProtocol || unknownProtocol.Protocol == nil ||
+		*unknownProtocol.Protocol != (records.UnknownProtocol{Layer: records.UnknownEtherType, Protocol: 0x88b5}) {
+		t.Errorf("unexpected diagnostic %s", unknownProtocol)
+	}
+}
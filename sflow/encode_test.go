@@ -2,7 +2,10 @@ package sflow
 
 import (
 	"bytes"
+	"net"
 	"os"
+	"reflect"
+	"sflowbeat/sflow/records"
 	"testing"
 )
 
@@ -92,3 +95,58 @@ func TestDecodeAndEncodeGenericEthernetCounterDatagram(t *testing.T) {
 		t.Errorf("expected\n%#v, got\n%#v", expectedGenericInterfaceCounters, genericInterfaceCounters)
 	}
 }
+
+func TestEncodeDecodeHTTPDatagram(t *testing.T) {
+	request := records.HTTPRequestFlow{
+		Method:    records.HTTPPost,
+		Protocol:  1001,
+		URILen:    9,
+		URI:       records.XDRString("/api/v1/x"),
+		HostLen:   15,
+		Host:      records.XDRString("api.example.com"),
+		ReqBytes:  42,
+		RespBytes: 7,
+		Duration:  1200,
+		Status:    201,
+
+		MethodName:   "POST",
+		ProtocolName: "HTTP/1.1",
+	}
+	counters := records.HTTPCounter{
+		MethodPostCount: 10,
+		Status2XXCount:  10,
+	}
+
+	samples := []Sample{
+		&FlowSample{SequenceNum: 1, SamplingRate: 100, Records: []records.Record{request}},
+		&CounterSample{SequenceNum: 2, Records: []records.Record{counters}},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(net.ParseIP("192.0.2.1"), 0, 1)
+	err := enc.Encode(buf, samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDecoder(bytes.NewReader(buf.Bytes()))
+
+	dgram, err := d.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dgram.Samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(dgram.Samples))
+	}
+
+	flowSample := dgram.Samples[0].(*FlowSample)
+	if !reflect.DeepEqual(flowSample.Records, []records.Record{request}) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", request, flowSample.Records)
+	}
+
+	counterSample := dgram.Samples[1].(*CounterSample)
+	if !reflect.DeepEqual(counterSample.Records, []records.Record{counters}) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", counters, counterSample.Records)
+	}
+}
@@ -2,8 +2,8 @@ package sflow
 
 import (
 	"bytes"
-	"github.com/fstelzer/sflow/records"
 	"reflect"
+	"sflowbeat/sflow/records"
 	"testing"
 )
 
@@ -5,21 +5,21 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"github.com/fstelzer/sflow/records"
 	"io"
+	"sflowbeat/sflow/records"
 )
 
 type FlowSample struct {
-	SequenceNum      uint32
-	SourceIdType     byte
-	SourceIdIndexVal uint32 // NOTE: this is 3 bytes in the datagram
-	SamplingRate     uint32
-	SamplePool       uint32
-	Drops            uint32
-	Input            uint32
-	Output           uint32
+	SequenceNum      uint32 `json:"sequenceNum"`
+	SourceIdType     byte   `json:"sourceIdType"`
+	SourceIdIndexVal uint32 `json:"sourceIdIndexVal"` // NOTE: this is 3 bytes in the datagram
+	SamplingRate     uint32 `json:"samplingRate"`
+	SamplePool       uint32 `json:"samplePool"`
+	Drops            uint32 `json:"drops"`
+	Input            uint32 `json:"input"`
+	Output           uint32 `json:"output"`
 	numRecords       uint32
-	Records          []records.Record
+	Records          []records.Record `json:"records"`
 }
 
 func (s FlowSample) String() string {
@@ -37,7 +37,7 @@ func (s *FlowSample) GetRecords() []records.Record {
 	return s.Records
 }
 
-func decodeFlowSample(r io.ReadSeeker) (Sample, error) {
+func decodeFlowSample(r io.ReadSeeker, rep *reporter) (Sample, error) {
 	s := &FlowSample{}
 
 	var err error
@@ -63,8 +63,8 @@ func decodeFlowSample(r io.ReadSeeker) (Sample, error) {
 	}
 
 	s.SourceIdIndexVal = uint32(srcIdIndexVal[2]) |
-		uint32(srcIdIndexVal[1]<<8) |
-		uint32(srcIdIndexVal[0]<<16)
+		uint32(srcIdIndexVal[1])<<8 |
+		uint32(srcIdIndexVal[0])<<16
 
 	err = binary.Read(r, binary.BigEndian, &s.SamplingRate)
 	if err != nil {
@@ -109,15 +109,25 @@ func decodeFlowSample(r io.ReadSeeker) (Sample, error) {
 			return nil, err
 		}
 
-		var rec records.Record
-		//fmt.Printf("sflow: Decoding record type %d with length %d\n", format, length)
+		if length > MaximumRecordLength {
+			return nil, fmt.Errorf("sflow: record length more than %d: %d",
+				MaximumRecordLength, length)
+		}
+
+		rr, err := newBoundedReader(r, length)
+		if err != nil {
+			return nil, err
+		}
+
+		rec, err := records.DecodeFlow(rr, format)
+		err = rr.check(format, length, err)
+		rep.checkRecord(format, length, rr.start, rr.consumed(), rec, err)
 
-		if rec, err = records.DecodeFlow(r, format); err != nil {
-			//return nil, err
-			_, err := r.Seek(int64(length), 1)
-			if err != nil {
-				return nil, err
-			}
+		// Continue with the next record in any case
+		if skipErr := rr.skip(); skipErr != nil {
+			return nil, skipErr
+		}
+		if err != nil {
 			continue
 		}
 
@@ -159,7 +169,7 @@ func (s *FlowSample) encode(w io.Writer) error {
 		return err
 	}
 	err = binary.Write(w, binary.BigEndian,
-		uint32(s.SourceIdType)|s.SourceIdIndexVal<<24)
+		uint32(s.SourceIdType)<<24|s.SourceIdIndexVal&0xffffff)
 	if err != nil {
 		return err
 	}
@@ -2,8 +2,8 @@ package sflow
 
 import (
 	"bytes"
-	"github.com/fstelzer/sflow/records"
 	"os"
+	"sflowbeat/sflow/records"
 	"testing"
 )
 
@@ -49,7 +49,7 @@ func TestDecodeEncodeAndDecodeFlowSample(t *testing.T) {
 	buf.Read(skip[:])
 
 	// bytes.Buffer is not an io.ReadSeeker. bytes.Reader is.
-	decodedSample, err := decodeFlowSample(bytes.NewReader(buf.Bytes()))
+	decodedSample, err := decodeFlowSample(bytes.NewReader(buf.Bytes()), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -84,3 +84,34 @@ func TestDecodeEncodeAndDecodeFlowSample(t *testing.T) {
 		t.Errorf("expected FrameLength to be 128, got %d", rec.HeaderSize)
 	}
 }
+
+func TestEncodeDecodeFlowSampleSourceID(t *testing.T) {
+	sample := &FlowSample{
+		SequenceNum:      1,
+		SourceIdType:     1,
+		SourceIdIndexVal: 0x012345,
+		SamplingRate:     1024,
+	}
+
+	buf := &bytes.Buffer{}
+
+	err := sample.encode(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// We need to skip the first 8 bytes. That's the header.
+	var skip [8]byte
+	buf.Read(skip[:])
+
+	decodedSample, err := decodeFlowSample(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := decodedSample.(*FlowSample)
+	if decoded.SourceIdType != sample.SourceIdType || decoded.SourceIdIndexVal != sample.SourceIdIndexVal {
+		t.Errorf("expected source id %d:%#x, got %d:%#x", sample.SourceIdType, sample.SourceIdIndexVal,
+			decoded.SourceIdType, decoded.SourceIdIndexVal)
+	}
+}
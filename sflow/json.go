@@ -0,0 +1,230 @@
+package sflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sflowbeat/sflow/records"
+)
+
+// JSONSchemaVersion is the version of the JSON representation of datagrams. Samples hold their type in
+// the "sampleType" member and records in the "recordType" member, which select the type to read them into.
+const JSONSchemaVersion = 1
+
+var (
+	ErrJSONSchemaVersion = errors.New("sflow: unsupported JSON schema version")
+	ErrSampleTypeMissing = errors.New("sflow: JSON sample without sampleType")
+)
+
+// The counter records of this package by their type
+var counterRecordJSONTypes = map[uint32]records.Record{
+	TypeGenericInterfaceCountersRecord: GenericInterfaceCounters{},
+	TypeEthernetCountersRecord:         EthernetCounters{},
+	TypeTokenRingCountersRecord:        TokenRingCounters{},
+	TypeVgCountersRecord:               VgCounters{},
+	TypeVlanCountersRecord:             VlanCounters{},
+	TypeProcessorCountersRecord:        ProcessorCounters{},
+	TypeHostCPUCountersRecord:          HostCPUCounters{},
+	TypeHostMemoryCountersRecord:       HostMemoryCounters{},
+	TypeHostDiskCountersRecord:         HostDiskCounters{},
+	TypeHostNetCountersRecord:          HostNetCounters{},
+}
+
+// MarshalJSON creates the JSON representation of the datagram including the JSONSchemaVersion
+func (d Datagram) MarshalJSON() ([]byte, error) {
+	type X Datagram
+	return json.Marshal(struct {
+		SchemaVersion int `json:"schemaVersion"`
+		X
+	}{JSONSchemaVersion, X(d)})
+}
+
+// UnmarshalJSON reads a datagram from its JSON representation. The version, IP version and number of samples
+// are derived from the datagram if they are left out.
+func (d *Datagram) UnmarshalJSON(value []byte) error {
+	type X Datagram
+	var x struct {
+		SchemaVersion int `json:"schemaVersion"`
+		X
+		Samples []json.RawMessage `json:"samples"`
+	}
+
+	if err := json.Unmarshal(value, &x); err != nil {
+		return err
+	}
+
+	if x.SchemaVersion != JSONSchemaVersion {
+		return fmt.Errorf("%w: %d", ErrJSONSchemaVersion, x.SchemaVersion)
+	}
+
+	*d = Datagram(x.X)
+	d.Samples = nil
+
+	for _, data := range x.Samples {
+		sample, err := unmarshalSampleJSON(data)
+		if err != nil {
+			return err
+		}
+		d.Samples = append(d.Samples, sample)
+	}
+
+	if d.Version == 0 {
+		d.Version = 5
+	}
+	if ip4 := d.IpAddress.To4(); ip4 != nil {
+		d.IpAddress = ip4
+	}
+	if d.IpVersion == 0 {
+		d.IpVersion = 2
+		if len(d.IpAddress) == 4 {
+			d.IpVersion = 1
+		}
+	}
+	if d.NumSamples == 0 {
+		d.NumSamples = uint32(len(d.Samples))
+	}
+
+	return nil
+}
+
+func unmarshalSampleJSON(data []byte) (Sample, error) {
+	var discriminator struct {
+		SampleType *uint32 `json:"sampleType"`
+	}
+
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return nil, err
+	}
+
+	if discriminator.SampleType == nil {
+		return nil, ErrSampleTypeMissing
+	}
+
+	var sample Sample
+
+	switch *discriminator.SampleType {
+	case TypeFlowSample:
+		sample = &FlowSample{}
+	case TypeCounterSample:
+		sample = &CounterSample{}
+	default:
+		return nil, fmt.Errorf("sample type %d: %w", *discriminator.SampleType, ErrUnknownSampleType)
+	}
+
+	if err := json.Unmarshal(data, sample); err != nil {
+		return nil, err
+	}
+
+	return sample, nil
+}
+
+// marshalRecordsJSON returns the JSON objects of the records with their type
+func marshalRecordsJSON(recs []records.Record) ([]json.RawMessage, error) {
+	objects := make([]json.RawMessage, 0, len(recs))
+
+	for _, rec := range recs {
+		b, err := records.MarshalRecordJSON(rec)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, b)
+	}
+
+	return objects, nil
+}
+
+// MarshalJSON creates the JSON representation of the flow sample with its type and the types of its records
+func (s *FlowSample) MarshalJSON() ([]byte, error) {
+	recs, err := marshalRecordsJSON(s.Records)
+	if err != nil {
+		return nil, err
+	}
+
+	type X FlowSample
+	return json.Marshal(struct {
+		SampleType int `json:"sampleType"`
+		*X
+		Records []json.RawMessage `json:"records"`
+	}{TypeFlowSample, (*X)(s), recs})
+}
+
+// UnmarshalJSON reads a flow sample from its JSON representation
+func (s *FlowSample) UnmarshalJSON(value []byte) error {
+	type X FlowSample
+	var x struct {
+		X
+		Records []json.RawMessage `json:"records"`
+	}
+
+	if err := json.Unmarshal(value, &x); err != nil {
+		return err
+	}
+
+	*s = FlowSample(x.X)
+	s.Records = nil
+
+	for _, data := range x.Records {
+		rec, err := records.UnmarshalFlowJSON(data)
+		if err != nil {
+			return err
+		}
+		s.Records = append(s.Records, rec)
+	}
+	s.numRecords = uint32(len(s.Records))
+
+	return nil
+}
+
+// MarshalJSON creates the JSON representation of the counter sample with its type and the types of its records
+func (s *CounterSample) MarshalJSON() ([]byte, error) {
+	recs, err := marshalRecordsJSON(s.Records)
+	if err != nil {
+		return nil, err
+	}
+
+	type X CounterSample
+	return json.Marshal(struct {
+		SampleType int `json:"sampleType"`
+		*X
+		Records []json.RawMessage `json:"records"`
+	}{TypeCounterSample, (*X)(s), recs})
+}
+
+// UnmarshalJSON reads a counter sample from its JSON representation
+func (s *CounterSample) UnmarshalJSON(value []byte) error {
+	type X CounterSample
+	var x struct {
+		X
+		Records []json.RawMessage `json:"records"`
+	}
+
+	if err := json.Unmarshal(value, &x); err != nil {
+		return err
+	}
+
+	*s = CounterSample(x.X)
+	s.Records = nil
+
+	for _, data := range x.Records {
+		recordType, err := records.RecordTypeJSON(data)
+		if err != nil {
+			return err
+		}
+
+		var rec records.Record
+		if prototype, found := counterRecordJSONTypes[recordType]; found {
+			rec, err = records.UnmarshalRecordJSON(prototype, data)
+		} else {
+			rec, err = records.UnmarshalCounterJSON(data)
+		}
+		if err != nil {
+			return err
+		}
+
+		s.Records = append(s.Records, rec)
+	}
+	s.numRecords = uint32(len(s.Records))
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package sflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"sflowbeat/sflow/records"
+)
+
+// testKnownRecords sets the number of records of the samples to the records which were decoded, as
+// records of unknown types are left out of the samples and cannot be written to JSON.
+func testKnownRecords(dgram *Datagram) {
+	for _, sample := range dgram.Samples {
+		switch s := sample.(type) {
+		case *FlowSample:
+			s.numRecords = uint32(len(s.Records))
+		case *CounterSample:
+			s.numRecords = uint32(len(s.Records))
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, dump := range testDumps {
+		_, expected := testReadDump(t, dump)
+		testKnownRecords(expected)
+
+		b, err := json.Marshal(expected)
+		if err != nil {
+			t.Fatalf("%s: %v", dump, err)
+		}
+
+		dgram := &Datagram{}
+		if err := json.Unmarshal(b, dgram); err != nil {
+			t.Fatalf("%s: %v", dump, err)
+		}
+
+		if !reflect.DeepEqual(dgram, expected) {
+			t.Errorf("%s: expected\n%+#v\n, got\n%+#v", dump, expected, dgram)
+		}
+
+		// Replay the datagram read from JSON into binary sFlow
+		encoder := NewEncoder(dgram.IpAddress, dgram.SubAgentId, dgram.SequenceNumber)
+		encoder.Uptime = dgram.Uptime
+
+		buf := &bytes.Buffer{}
+		if err := encoder.Encode(buf, dgram.Samples); err != nil {
+			t.Fatalf("%s: %v", dump, err)
+		}
+
+		replayed, err := NewDecoder(bytes.NewReader(buf.Bytes())).Decode()
+		if err != nil {
+			t.Fatalf("%s: %v", dump, err)
+		}
+
+		if !reflect.DeepEqual(replayed, expected) {
+			t.Errorf("%s: expected the replayed datagram\n%+#v\n, got\n%+#v", dump, expected, replayed)
+		}
+	}
+}
+
+func TestJSONFixture(t *testing.T) {
+	fixture := `{
+		"schemaVersion": 1,
+		"ipAddress": "192.0.2.1",
+		"subAgentId": 1,
+		"sequenceNumber": 100,
+		"uptime": 5000,
+		"samples": [
+			{
+				"sampleType": 1,
+				"sequenceNum": 7,
+				"sourceIdType": 0,
+				"sourceIdIndexVal": 3,
+				"samplingRate": 1000,
+				"input": 3,
+				"output": 4,
+				"records": [
+					{"recordType": 1001, "SourceVlan": 10, "DestinationVlan": 20}
+				]
+			},
+			{
+				"sampleType": 2,
+				"sequenceNum": 8,
+				"sourceIdIndexVal": 3,
+				"records": [
+					{"recordType": 5, "ID": 10, "Octets": 1500, "UnicastPackets": 1}
+				]
+			}
+		]
+	}`
+
+	dgram := &Datagram{}
+	if err := json.Unmarshal([]byte(fixture), dgram); err != nil {
+		t.Fatal(err)
+	}
+
+	if dgram.Version != 5 || dgram.IpVersion != 1 || dgram.NumSamples != 2 || len(dgram.IpAddress) != 4 {
+		t.Errorf("unexpected datagram header %+v", dgram)
+	}
+
+	encoder := NewEncoder(dgram.IpAddress, dgram.SubAgentId, dgram.SequenceNumber)
+	encoder.Uptime = dgram.Uptime
+
+	buf := &bytes.Buffer{}
+	if err := encoder.Encode(buf, dgram.Samples); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := NewDecoder(bytes.NewReader(buf.Bytes())).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decoded, dgram) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", dgram, decoded)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	for _, c := range []struct {
+		json     string
+		expected error
+	}{
+		{`{"samples": []}`, ErrJSONSchemaVersion},
+		{`{"schemaVersion": 2}`, ErrJSONSchemaVersion},
+		{`{"schemaVersion": 1, "samples": [{"sequenceNum": 1}]}`, ErrSampleTypeMissing},
+		{`{"schemaVersion": 1, "samples": [{"sampleType": 3}]}`, ErrUnknownSampleType},
+		{`{"schemaVersion": 1, "samples": [{"sampleType": 1, "records": [{}]}]}`, records.ErrRecordTypeMissing},
+		{`{"schemaVersion": 1, "samples": [{"sampleType": 1, "records": [{"recordType": 9}]}]}`, records.ErrUnknownRecordType},
+		{`{"schemaVersion": 1, "samples": [{"sampleType": 2, "records": [{"recordType": 9}]}]}`, records.ErrUnknownRecordType},
+	} {
+		if err := json.Unmarshal([]byte(c.json), &Datagram{}); !errors.Is(err, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.json, c.expected, err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package sflow
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrSeekOutOfBounds = errors.New("sflow: seek outside of the declared length")
+
+// LengthError reports a sample or record whose decoder did not consume exactly its declared length
+type LengthError struct {
+	Type     uint32 /* sample or record type */
+	Length   uint32 /* declared length */
+	Consumed int64  /* bytes consumed by the decoder */
+	Overrun  bool   /* the decoder needed more than the declared length */
+}
+
+func (e *LengthError) Error() string {
+	if e.Overrun {
+		return fmt.Sprintf("sflow: type %d needs more than its declared length %d", e.Type, e.Length)
+	}
+	return fmt.Sprintf("sflow: type %d declares length %d but %d bytes were decoded", e.Type, e.Length, e.Consumed)
+}
+
+// boundedReader reads a sample or record of a declared length from the underlying reader.
+// Reads stop at the end of the declared length, and at the end of the enclosing sample for records.
+// The offsets are kept in the coordinates of the underlying reader, so nested boundedReaders share it.
+type boundedReader struct {
+	r     io.ReadSeeker
+	start int64
+	end   int64
+}
+
+// newBoundedReader returns a reader for the next length bytes of r
+func newBoundedReader(r io.ReadSeeker, length uint32) (*boundedReader, error) {
+	b := &boundedReader{r: r}
+	end := int64(-1)
+
+	if parent, ok := r.(*boundedReader); ok {
+		b.r = parent.r
+		end = parent.end
+	}
+
+	var err error
+	if b.start, err = b.r.Seek(0, io.SeekCurrent); err != nil {
+		return nil, err
+	}
+
+	b.end = b.start + int64(length)
+	if end != -1 && b.end > end {
+		b.end = end
+	}
+
+	return b, nil
+}
+
+func (b *boundedReader) Read(p []byte) (int, error) {
+	pos, err := b.r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+
+	if pos >= b.end {
+		return 0, io.EOF
+	}
+	if remaining := b.end - pos; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	return b.r.Read(p)
+}
+
+// Seek sets the offset relative to the start of the declared length
+func (b *boundedReader) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+
+	switch whence {
+	case io.SeekStart:
+		pos = b.start + offset
+	case io.SeekCurrent:
+		current, err := b.r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, err
+		}
+		pos = current + offset
+	case io.SeekEnd:
+		pos = b.end + offset
+	}
+
+	if pos < b.start || pos > b.end {
+		return 0, ErrSeekOutOfBounds
+	}
+
+	if _, err := b.r.Seek(pos, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return pos - b.start, nil
+}
+
+// consumed returns the number of bytes read so far
+func (b *boundedReader) consumed() int64 {
+	pos, _ := b.r.Seek(0, io.SeekCurrent)
+	return pos - b.start
+}
+
+// check turns the outcome of decoding the declared length of the given type into a LengthError
+// if the decoder ran out of data or did not consume all of it
+func (b *boundedReader) check(typ, length uint32, err error) error {
+	return checkLength(typ, length, b.end-b.start, b.consumed(), err)
+}
+
+// checkLength turns the outcome of decoding a sample or record of the given type and declared length into
+// a LengthError if the decoder ran out of the available data or did not consume all of it
+func checkLength(typ, length uint32, available, consumed int64, err error) error {
+	switch {
+	case (err == io.EOF || err == io.ErrUnexpectedEOF) && consumed >= available:
+		return &LengthError{Type: typ, Length: length, Consumed: consumed, Overrun: true}
+	case err != nil:
+		return err
+	case consumed != int64(length):
+		return &LengthError{Type: typ, Length: length, Consumed: consumed}
+	}
+
+	return nil
+}
+
+// skip moves the underlying reader to the end of the declared length, where the next sample or record starts
+func (b *boundedReader) skip() error {
+	_, err := b.r.Seek(b.end, io.SeekStart)
+	return err
+}
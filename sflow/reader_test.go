@@ -0,0 +1,169 @@
+package sflow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"sflowbeat/sflow/records"
+)
+
+// testRawRecord is a flow record encoded with an arbitrary type, declared length and data
+type testRawRecord struct {
+	recordType uint32
+	length     uint32
+	data       []byte
+}
+
+func (r testRawRecord) RecordType() int    { return int(r.recordType) }
+func (r testRawRecord) RecordName() string { return "testRawRecord" }
+func (r testRawRecord) Encode(w io.Writer) error {
+	if err := binary.Write(w, binary.BigEndian, []uint32{r.recordType, r.length}); err != nil {
+		return err
+	}
+	_, err := w.Write(r.data)
+	return err
+}
+
+// testRawSample is a sample encoded with an arbitrary type and data
+type testRawSample struct {
+	sampleType uint32
+	data       []byte
+}
+
+func (s *testRawSample) SampleType() int              { return int(s.sampleType) }
+func (s *testRawSample) GetRecords() []records.Record { return nil }
+func (s *testRawSample) encode(w io.Writer) error {
+	if err := binary.Write(w, binary.BigEndian, []uint32{s.sampleType, uint32(len(s.data))}); err != nil {
+		return err
+	}
+	_, err := w.Write(s.data)
+	return err
+}
+
+// testSwitchRecord returns an extended switch record with the given source VLAN, encoded with the given declared length
+func testSwitchRecord(vlan uint32, length uint32, data ...byte) testRawRecord {
+	b := &bytes.Buffer{}
+	binary.Write(b, binary.BigEndian, []uint32{vlan, 1, vlan, 1})
+	b.Write(data)
+
+	return testRawRecord{recordType: records.TypeExtendedSwitchFlowRecord, length: length, data: b.Bytes()}
+}
+
+func testDecodeWithDiagnostics(t *testing.T, samples []Sample) (*Datagram, []Diagnostic) {
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(net.ParseIP("192.0.2.1"), 0, 1)
+	if err := enc.Encode(buf, samples); err != nil {
+		t.Fatal(err)
+	}
+
+	var diagnostics []Diagnostic
+
+	d := NewDecoder(bytes.NewReader(buf.Bytes()))
+	d.SetDiagnostics(DiagnosticsFunc(func(diagnostic Diagnostic) {
+		diagnostics = append(diagnostics, diagnostic)
+	}))
+
+	dgram, err := d.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dgram, diagnostics
+}
+
+func TestDecodeRecordLengthMismatch(t *testing.T) {
+	samples := []Sample{
+		&FlowSample{SequenceNum: 1, Records: []records.Record{
+			// Declares 4 bytes of trailing data
+			testSwitchRecord(10, 20, 0xde, 0xad, 0xbe, 0xef),
+			// Ends before the destination priority
+			testRawRecord{recordType: records.TypeExtendedSwitchFlowRecord, length: 12, data: make([]byte, 12)},
+			testSwitchRecord(30, 16),
+		}},
+	}
+
+	dgram, diagnostics := testDecodeWithDiagnostics(t, samples)
+
+	expected := []records.Record{records.ExtendedSwitchFlow{SourceVlan: 30, SourcePriority: 1, DestinationVlan: 30, DestinationPriority: 1}}
+	if recs := dgram.Samples[0].GetRecords(); !reflect.DeepEqual(recs, expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, recs)
+	}
+
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diagnostics)
+	}
+
+	expectedErrors := []*LengthError{
+		{Type: records.TypeExtendedSwitchFlowRecord, Length: 20, Consumed: 16},
+		{Type: records.TypeExtendedSwitchFlowRecord, Length: 12, Consumed: 12, Overrun: true},
+	}
+	for i, expected := range expectedErrors {
+		var err *LengthError
+		if diagnostics[i].Kind != DiagnosticLengthMismatch || !errors.As(diagnostics[i].Err, &err) || *err != *expected {
+			t.Errorf("expected a length mismatch %+v, got %s", expected, diagnostics[i])
+		}
+	}
+}
+
+func TestDecodeSkipsUnknownAndMalformedSamples(t *testing.T) {
+	samples := []Sample{
+		&testRawSample{sampleType: TypeExpandedFlowSample, data: make([]byte, 12)},
+		// A flow sample too short for its fields
+		&testRawSample{sampleType: TypeFlowSample, data: make([]byte, 8)},
+		&FlowSample{SequenceNum: 3, Records: []records.Record{testSwitchRecord(10, 16)}},
+	}
+
+	dgram, diagnostics := testDecodeWithDiagnostics(t, samples)
+
+	if len(dgram.Samples) != 1 || dgram.Samples[0].(*FlowSample).SequenceNum != 3 {
+		t.Fatalf("expected only the valid flow sample, got %v", dgram.Samples)
+	}
+
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diagnostics)
+	}
+	if d := diagnostics[0]; d.Kind != DiagnosticUnknownSample || d.SampleType != TypeExpandedFlowSample || d.Offset != 36 {
+		t.Errorf("unexpected diagnostic %s", d)
+	}
+	if d := diagnostics[1]; d.Kind != DiagnosticLengthMismatch || d.SampleType != TypeFlowSample || d.Offset != 56 {
+		t.Errorf("unexpected diagnostic %s", d)
+	}
+}
+
+func TestBoundedReader(t *testing.T) {
+	r := bytes.NewReader([]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	r.Seek(2, io.SeekStart)
+
+	sample, err := newBoundedReader(r, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A record declaring more than the rest of the sample is bounded by the sample
+	sample.Seek(1, io.SeekCurrent)
+	record, err := newBoundedReader(sample, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := io.ReadAll(record)
+	if err != nil || !bytes.Equal(b, []byte{3, 4, 5}) {
+		t.Errorf("expected [3 4 5], got %v (%v)", b, err)
+	}
+
+	if _, err := sample.Seek(5, io.SeekStart); err != ErrSeekOutOfBounds {
+		t.Errorf("expected %v, got %v", ErrSeekOutOfBounds, err)
+	}
+
+	if err := sample.skip(); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := r.ReadByte(); b != 6 {
+		t.Errorf("expected to continue after the sample at 6, got %d", b)
+	}
+}
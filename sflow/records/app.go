@@ -0,0 +1,404 @@
+package records
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Application operation status codes
+const (
+	AppStatusOK             = 0
+	AppStatusOther          = 1
+	AppStatusTimeout        = 2
+	AppStatusInternalError  = 3
+	AppStatusBadRequest     = 4
+	AppStatusForbidden      = 5
+	AppStatusTooLarge       = 6
+	AppStatusNotImplemented = 7
+	AppStatusNotFound       = 8
+	AppStatusUnavailable    = 9
+	AppStatusUnauthorized   = 10
+)
+
+var appStatusNames = map[uint32]string{
+	AppStatusOK:             "OK",
+	AppStatusOther:          "OTHER",
+	AppStatusTimeout:        "TIMEOUT",
+	AppStatusInternalError:  "INTERNAL_ERROR",
+	AppStatusBadRequest:     "BAD_REQUEST",
+	AppStatusForbidden:      "FORBIDDEN",
+	AppStatusTooLarge:       "TOO_LARGE",
+	AppStatusNotImplemented: "NOT_IMPLEMENTED",
+	AppStatusNotFound:       "NOT_FOUND",
+	AppStatusUnavailable:    "UNAVAILABLE",
+	AppStatusUnauthorized:   "UNAUTHORIZED",
+}
+
+// AppStatusName returns the name of an application operation status code
+func AppStatusName(status uint32) string {
+	return lookupName(appStatusNames, status)
+}
+
+// AppContext describes an application operation
+type AppContext struct {
+	ApplicationLen uint32
+	Application    XDRString `lengthLookUp:"ApplicationLen" maxLength:"32"` /* e.g. "payment" */
+	OperationLen   uint32
+	Operation      XDRString `lengthLookUp:"OperationLen" maxLength:"32"` /* e.g. "get.customer.name" */
+	AttributesLen  uint32
+	Attributes     XDRString `lengthLookUp:"AttributesLen" maxLength:"255"` /* URL query string encoded attributes, e.g. "cc=visa&loc=mobile" */
+}
+
+func (c AppContext) calculateBinarySize() int {
+	var size int
+
+	size += binary.Size(c.ApplicationLen)
+	size += paddedLength(len(c.Application))
+	size += binary.Size(c.OperationLen)
+	size += paddedLength(len(c.Operation))
+	size += binary.Size(c.AttributesLen)
+	size += paddedLength(len(c.Attributes))
+
+	return size
+}
+
+// AppOperationFlow - TypeAppOperationFlowRecord
+type AppOperationFlow struct {
+	Context        AppContext
+	StatusDescrLen uint32
+	StatusDescr    XDRString `lengthLookUp:"StatusDescrLen" maxLength:"64"` /* additional text describing status (e.g. "unknown client") */
+	ReqBytes       uint64    /* size of request body (exclude headers) */
+	RespBytes      uint64    /* size of response body (exclude headers) */
+	Duration       uint32    /* duration of the operation (in microseconds) */
+	Status         uint32
+	StatusName     string `ignoreOnMarshal:"true"`
+}
+
+func (f AppOperationFlow) String() string {
+	type X AppOperationFlow
+	x := X(f)
+	return fmt.Sprintf("AppOperationFlow: %+v", x)
+}
+
+// RecordName returns the Name of this flow record
+func (f AppOperationFlow) RecordName() string {
+	return "AppOperationFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f AppOperationFlow) RecordType() int {
+	return TypeAppOperationFlowRecord
+}
+
+func (f AppOperationFlow) calculateBinarySize() int {
+	var size int
+
+	size += f.Context.calculateBinarySize()
+	size += binary.Size(f.StatusDescrLen)
+	size += paddedLength(len(f.StatusDescr))
+	size += binary.Size(f.ReqBytes)
+	size += binary.Size(f.RespBytes)
+	size += binary.Size(f.Duration)
+	size += binary.Size(f.Status)
+
+	return size
+}
+
+func (f *AppOperationFlow) PostDecode() error {
+	f.StatusName = AppStatusName(f.Status)
+
+	return nil
+}
+
+func (f AppOperationFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
+}
+
+// AppParentContextFlow - TypeAppParentContextFlowRecord
+// Optional parent context information for a sampled client operation.
+type AppParentContextFlow struct {
+	Context AppContext
+}
+
+func (f AppParentContextFlow) String() string {
+	type X AppParentContextFlow
+	x := X(f)
+	return fmt.Sprintf("AppParentContextFlow: %+v", x)
+}
+
+// RecordName returns the Name of this flow record
+func (f AppParentContextFlow) RecordName() string {
+	return "AppParentContextFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f AppParentContextFlow) RecordType() int {
+	return TypeAppParentContextFlowRecord
+}
+
+func (f AppParentContextFlow) calculateBinarySize() int {
+	return f.Context.calculateBinarySize()
+}
+
+func (f AppParentContextFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
+}
+
+// AppInitiatorFlow - TypeAppInitiatorFlowRecord
+// Actor requesting the application operation.
+type AppInitiatorFlow struct {
+	ActorLen uint32
+	Actor    XDRString `lengthLookUp:"ActorLen" maxLength:"64"`
+}
+
+func (f AppInitiatorFlow) String() string {
+	type X AppInitiatorFlow
+	x := X(f)
+	return fmt.Sprintf("AppInitiatorFlow: %+v", x)
+}
+
+// RecordName returns the Name of this flow record
+func (f AppInitiatorFlow) RecordName() string {
+	return "AppInitiatorFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f AppInitiatorFlow) RecordType() int {
+	return TypeAppInitiatorFlowRecord
+}
+
+func (f AppInitiatorFlow) calculateBinarySize() int {
+	return binary.Size(f.ActorLen) + paddedLength(len(f.Actor))
+}
+
+func (f AppInitiatorFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
+}
+
+// AppTargetFlow - TypeAppTargetFlowRecord
+// Actor targeted by the application operation.
+type AppTargetFlow struct {
+	ActorLen uint32
+	Actor    XDRString `lengthLookUp:"ActorLen" maxLength:"64"`
+}
+
+func (f AppTargetFlow) String() string {
+	type X AppTargetFlow
+	x := X(f)
+	return fmt.Sprintf("AppTargetFlow: %+v", x)
+}
+
+// RecordName returns the Name of this flow record
+func (f AppTargetFlow) RecordName() string {
+	return "AppTargetFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f AppTargetFlow) RecordType() int {
+	return TypeAppTargetFlowRecord
+}
+
+func (f AppTargetFlow) calculateBinarySize() int {
+	return binary.Size(f.ActorLen) + paddedLength(len(f.Actor))
+}
+
+func (f AppTargetFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
+}
+
+// AppOperationsCounter - TypeAppOperationsCounterRecord
+// Number of operations per status code.
+type AppOperationsCounter struct {
+	ApplicationLen uint32
+	Application    XDRString `lengthLookUp:"ApplicationLen" maxLength:"32"`
+	Success        uint32
+	Other          uint32
+	Timeout        uint32
+	InternalError  uint32
+	BadRequest     uint32
+	Forbidden      uint32
+	TooLarge       uint32
+	NotImplemented uint32
+	NotFound       uint32
+	Unavailable    uint32
+	Unauthorized   uint32
+}
+
+func (c AppOperationsCounter) String() string {
+	type X AppOperationsCounter
+	x := X(c)
+	return fmt.Sprintf("AppOperationsCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c AppOperationsCounter) RecordName() string {
+	return "AppOperationsCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c AppOperationsCounter) RecordType() int {
+	return TypeAppOperationsCounterRecord
+}
+
+func (c AppOperationsCounter) calculateBinarySize() int {
+	// The application name and 11 status counters
+	return binary.Size(c.ApplicationLen) + paddedLength(len(c.Application)) + 11*4
+}
+
+func (c AppOperationsCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, c)
+}
+
+// AppResourcesCounter - TypeAppResourcesCounterRecord
+type AppResourcesCounter struct {
+	UserTime   uint32 /* user time (ms) */
+	SystemTime uint32 /* system time (ms) */
+	MemUsed    uint64 /* memory used in bytes */
+	MemMax     uint64 /* max memory in bytes */
+	FdOpen     uint32 /* number of open file descriptors */
+	FdMax      uint32 /* max number of file descriptors */
+	ConnOpen   uint32 /* number of open network connections */
+	ConnMax    uint32 /* max number of network connections */
+}
+
+func (c AppResourcesCounter) String() string {
+	type X AppResourcesCounter
+	x := X(c)
+	return fmt.Sprintf("AppResourcesCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c AppResourcesCounter) RecordName() string {
+	return "AppResourcesCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c AppResourcesCounter) RecordType() int {
+	return TypeAppResourcesCounterRecord
+}
+
+func (c AppResourcesCounter) calculateBinarySize() int {
+	return binary.Size(c)
+}
+
+func (c AppResourcesCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, c)
+}
+
+// AppWorkersCounter - TypeAppWorkersCounterRecord
+type AppWorkersCounter struct {
+	WorkersActive uint32 /* number of active workers */
+	WorkersIdle   uint32 /* number of idle workers */
+	WorkersMax    uint32 /* max number of workers */
+	ReqDelayed    uint32 /* number of requests delayed */
+	ReqDropped    uint32 /* number of requests dropped */
+}
+
+func (c AppWorkersCounter) String() string {
+	type X AppWorkersCounter
+	x := X(c)
+	return fmt.Sprintf("AppWorkersCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c AppWorkersCounter) RecordName() string {
+	return "AppWorkersCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c AppWorkersCounter) RecordType() int {
+	return TypeAppWorkersCounterRecord
+}
+
+func (c AppWorkersCounter) calculateBinarySize() int {
+	return binary.Size(c)
+}
+
+func (c AppWorkersCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, c)
+}
@@ -0,0 +1,90 @@
+package records
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeAppOperationFlowRecord(t *testing.T) {
+	rec := AppOperationFlow{
+		Context: AppContext{
+			ApplicationLen: 7,
+			Application:    XDRString("payment"),
+			OperationLen:   17,
+			Operation:      XDRString("get.customer.name"),
+			AttributesLen:  18,
+			Attributes:     XDRString("cc=visa&loc=mobile"),
+		},
+		StatusDescrLen: 14,
+		StatusDescr:    XDRString("unknown client"),
+		ReqBytes:       128,
+		RespBytes:      4096,
+		Duration:       1500,
+		Status:         AppStatusUnauthorized,
+		StatusName:     "UNAUTHORIZED",
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+rec.calculateBinarySize() {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+rec.calculateBinarySize(), b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeFlow(b, TypeAppOperationFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestEncodeDecodeAppOperationsCounterRecord(t *testing.T) {
+	rec := AppOperationsCounter{
+		ApplicationLen: 5,
+		Application:    XDRString("users"),
+		Success:        1000,
+		Timeout:        3,
+		NotFound:       12,
+		Unauthorized:   1,
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+rec.calculateBinarySize() {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+rec.calculateBinarySize(), b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeCounter(b, TypeAppOperationsCounterRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestAppStatusName(t *testing.T) {
+	if name := AppStatusName(AppStatusTimeout); name != "TIMEOUT" {
+		t.Errorf("expected TIMEOUT, got %s", name)
+	}
+
+	if name := AppStatusName(42); name != "UNKNOWN(42)" {
+		t.Errorf("expected UNKNOWN(42), got %s", name)
+	}
+}
@@ -0,0 +1,107 @@
+package records
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// DecodeApplicationLayer enables peeking into the payload following the TCP and UDP headers of
+// sampled packets to decode DNS messages, TLS ClientHellos, HTTP requests and the payloads of
+// registered port dissectors. It is disabled by default, as it costs CPU on every sampled header.
+var DecodeApplicationLayer = false
+
+// HTTPPorts are the TCP destination ports whose payload is checked for a HTTP request if no dissector
+// is registered for the ports of the packet. It must be set before decoding starts.
+var HTTPPorts = map[uint16]bool{
+	80:   true,
+	8080: true,
+}
+
+var httpRequestMethods = map[string]bool{
+	"GET":     true,
+	"HEAD":    true,
+	"POST":    true,
+	"PUT":     true,
+	"DELETE":  true,
+	"OPTIONS": true,
+	"PATCH":   true,
+	"CONNECT": true,
+	"TRACE":   true,
+}
+
+// HTTPRequestHeader is the request line and Host header of a plaintext HTTP request as found in RawPacketFlow.Header
+// URI is cut short and Version and Host are missing if the sampled header ends within them.
+type HTTPRequestHeader struct {
+	Method  string `json:"method"`
+	URI     string `json:"uri"`
+	Version string `json:"version,omitempty"`
+	Host    string `json:"host,omitempty"`
+}
+
+// decodePayload decodes the payload of a TCP or UDP packet from h into p using the dissector registered
+// for its ports. TCP payloads to one of the HTTPPorts without a dissector are checked for a HTTP request.
+// Payloads of packets embedded in ICMP error messages are not decoded.
+func decodePayload(protocol uint8, srcPort, dstPort uint16, h *bytes.Reader, p *DecodedPacket) error {
+	if !DecodeApplicationLayer || p.embedded || h.Len() == 0 {
+		return nil
+	}
+
+	if d, found := portDissector(protocol, srcPort, dstPort); found {
+		return d(h, p)
+	}
+
+	if protocol == IPProtocolTCP && HTTPPorts[dstPort] {
+		if http, ok := decodeHTTPRequestHeader(readPayload(h)); ok {
+			p.application().HTTP = &http
+		}
+	}
+	return nil
+}
+
+// readPayload reads the remainder of the sampled header
+func readPayload(h *bytes.Reader) []byte {
+	payload := make([]byte, h.Len())
+	n, _ := io.ReadFull(h, payload)
+	return payload[:n]
+}
+
+// decodeHTTPRequestHeader decodes the request line and Host header of a HTTP/1.x request.
+// It returns false if b does not start with a request line.
+func decodeHTTPRequestHeader(b []byte) (HTTPRequestHeader, bool) {
+	http := HTTPRequestHeader{}
+
+	lines := strings.Split(string(b), "\r\n")
+
+	// Method, URI and version separated by single spaces
+	requestLine := strings.SplitN(lines[0], " ", 3)
+	if len(requestLine) < 2 || !httpRequestMethods[requestLine[0]] {
+		return http, false
+	}
+
+	http.Method = requestLine[0]
+	http.URI = requestLine[1]
+
+	// A complete request line is followed by at least one line break
+	if len(lines) == 1 {
+		return http, true
+	}
+
+	if len(requestLine) == 3 {
+		http.Version = requestLine[2]
+	}
+
+	// The last line may be truncated
+	for _, line := range lines[1 : len(lines)-1] {
+		if line == "" {
+			break
+		}
+
+		if colon := strings.IndexByte(line, ':'); colon != -1 && strings.EqualFold(line[:colon], "Host") {
+			http.Host = strings.TrimSpace(line[colon+1:])
+			break
+		}
+	}
+
+	return http, true
+}
@@ -0,0 +1,79 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testHTTPRequestTCPHeader = []byte{
+	// TCP 50000 -> 8080, PSH+ACK
+	0xc3, 0x50, 0x1f, 0x90, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+	0x50, 0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00,
+}
+
+// testApplicationLayer enables decoding the application layer for the duration of the test
+func testApplicationLayer(t *testing.T) {
+	DecodeApplicationLayer = true
+	t.Cleanup(func() { DecodeApplicationLayer = false })
+}
+
+func TestDecodeHTTPRequestHeader(t *testing.T) {
+	testApplicationLayer(t)
+
+	payload := append([]byte{}, testHTTPRequestTCPHeader...)
+	payload = append(payload, "GET /index.html?q=1 HTTP/1.1\r\nUser-Agent: curl/8.0\r\nhost: www.example.com:8080\r\nAccept: */*\r\n"...)
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4Packet(IPProtocolTCP, payload))
+
+	expected := HTTPRequestHeader{
+		Method:  "GET",
+		URI:     "/index.html?q=1",
+		Version: "HTTP/1.1",
+		Host:    "www.example.com:8080",
+	}
+
+	if http := p.application().HTTP; !reflect.DeepEqual(http, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, http)
+	}
+}
+
+func TestDecodeHTTPRequestHeaderTruncated(t *testing.T) {
+	testApplicationLayer(t)
+
+	payload := append([]byte{}, testHTTPRequestTCPHeader...)
+	payload = append(payload, "POST /api/v1/very/long/pa"...)
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4Packet(IPProtocolTCP, payload))
+
+	expected := HTTPRequestHeader{Method: "POST", URI: "/api/v1/very/long/pa"}
+	if http := p.application().HTTP; !reflect.DeepEqual(http, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, http)
+	}
+}
+
+func TestDecodeApplicationLayerDisabled(t *testing.T) {
+	payload := append([]byte{}, testHTTPRequestTCPHeader...)
+	payload = append(payload, "GET / HTTP/1.1\r\n"...)
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4Packet(IPProtocolTCP, payload))
+
+	if http := p.application().HTTP; http != nil {
+		t.Errorf("expected no application layer decoding, got %+v", http)
+	}
+}
+
+func TestDecodeHTTPRequestHeaderOtherPort(t *testing.T) {
+	testApplicationLayer(t)
+
+	payload := append([]byte{}, testHTTPRequestTCPHeader...)
+	payload = append(payload, "GET / HTTP/1.1\r\n"...)
+
+	// TCP 50000 -> 9000
+	payload[2], payload[3] = 0x23, 0x28
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4Packet(IPProtocolTCP, payload))
+
+	if http := p.application().HTTP; http != nil {
+		t.Errorf("expected no HTTP request on port 9000, got %+v", http)
+	}
+}
@@ -0,0 +1,77 @@
+package records
+
+import (
+	"bytes"
+	"io"
+	"net"
+)
+
+// ARP Operations
+const (
+	ARPOperationRequest        = 1
+	ARPOperationReply          = 2
+	ARPOperationRequestReverse = 3
+	ARPOperationReplyReverse   = 4
+)
+
+var arpOperationNames = map[uint32]string{
+	ARPOperationRequest:        "request",
+	ARPOperationReply:          "reply",
+	ARPOperationRequestReverse: "reverse-request",
+	ARPOperationReplyReverse:   "reverse-reply",
+}
+
+// ARPHeader as found in RawPacketFlow.Header
+// The addresses are only decoded for Ethernet hardware and IPv4 protocol addresses.
+type ARPHeader struct {
+	HardwareType  uint16 `json:"hardwareType"`
+	ProtocolType  uint16 `json:"protocolType"`
+	HardwareLen   uint8  `json:"hardwareLen"`
+	ProtocolLen   uint8  `json:"protocolLen"`
+	Operation     uint16 `json:"operation"`
+	OperationName string `ignoreOnMarshal:"true" json:"operationName"`
+
+	SenderMAC HardwareAddr `ignoreOnMarshal:"true" json:"senderMAC,omitempty"`
+	SenderIP  net.IP       `ignoreOnMarshal:"true" json:"senderIP,omitempty"`
+	TargetMAC HardwareAddr `ignoreOnMarshal:"true" json:"targetMAC,omitempty"`
+	TargetIP  net.IP       `ignoreOnMarshal:"true" json:"targetIP,omitempty"`
+}
+
+// IsGratuitous returns true for ARP messages announcing the senders own address
+func (arp ARPHeader) IsGratuitous() bool {
+	return arp.SenderIP != nil && arp.SenderIP.Equal(arp.TargetIP)
+}
+
+// decodeARPHeader decodes an ARP message
+func decodeARPHeader(h *bytes.Reader) (ARPHeader, error) {
+	arp := ARPHeader{}
+
+	if _, err := decodeInto(h, &arp); err != nil {
+		return arp, err
+	}
+
+	arp.OperationName = lookupName(arpOperationNames, uint32(arp.Operation))
+
+	if arp.HardwareLen != 6 || arp.ProtocolLen != net.IPv4len || arp.ProtocolType != HeaderTypeIPv4 {
+		return arp, nil
+	}
+
+	addresses := make([]byte, 2*(6+net.IPv4len))
+	if _, err := io.ReadFull(h, addresses); err != nil {
+		return arp, err
+	}
+
+	arp.SenderMAC = HardwareAddr(addresses[0:6])
+	arp.SenderIP = net.IP(addresses[6:10])
+	arp.TargetMAC = HardwareAddr(addresses[10:16])
+	arp.TargetIP = net.IP(addresses[16:20])
+
+	return arp, nil
+}
+
+// dissectARP decodes an ARP header from h into p
+func dissectARP(h *bytes.Reader, p *DecodedPacket) error {
+	arp, err := decodeARPHeader(h)
+	p.network().ARP = &arp
+	return err
+}
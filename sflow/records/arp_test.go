@@ -0,0 +1,50 @@
+package records
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeARPRequest(t *testing.T) {
+	header := []byte{
+		// Ethernet
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55,
+		// 802.1Q VLAN 100, priority 5
+		0x81, 0x00, 0xa0, 0x64,
+		// ARP
+		0x08, 0x06,
+		0x00, 0x01, 0x08, 0x00, 0x06, 0x04, 0x00, 0x01,
+		0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 192, 0, 2, 1,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 192, 0, 2, 254,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolEthernetISO8023, header)
+
+	expectedTags := []VLANTag{{TCI: 0xa064, Priority: 5, ID: 100}}
+	if tags := p.VLANs; !reflect.DeepEqual(tags, expectedTags) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expectedTags, tags)
+	}
+
+	expected := ARPHeader{
+		HardwareType:  1,
+		ProtocolType:  HeaderTypeIPv4,
+		HardwareLen:   6,
+		ProtocolLen:   4,
+		Operation:     ARPOperationRequest,
+		OperationName: "request",
+		SenderMAC:     HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+		SenderIP:      net.IP{192, 0, 2, 1},
+		TargetMAC:     HardwareAddr{0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		TargetIP:      net.IP{192, 0, 2, 254},
+	}
+
+	arp := p.network().ARP
+	if !reflect.DeepEqual(arp, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, arp)
+	}
+
+	if arp != nil && arp.IsGratuitous() {
+		t.Errorf("expected a non gratuitous ARP request")
+	}
+}
@@ -0,0 +1,191 @@
+package records
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Broadcom percentages are expressed in hundredths of a percent (e.g. 100 = 1%).
+// An unknown value is reported as BroadcomPercentageUnknown.
+const BroadcomPercentageUnknown = -1
+
+// BroadcomDeviceBuffersCounter - TypeBroadcomDeviceBuffersCounterRecord
+// Peak device level buffer utilization since the last export.
+type BroadcomDeviceBuffersCounter struct {
+	UnicastPc   int32 /* unicast buffers percentage utilization */
+	MulticastPc int32 /* multicast buffers percentage utilization */
+}
+
+func (c BroadcomDeviceBuffersCounter) String() string {
+	type X BroadcomDeviceBuffersCounter
+	x := X(c)
+	return fmt.Sprintf("BroadcomDeviceBuffersCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c BroadcomDeviceBuffersCounter) RecordName() string {
+	return "BroadcomDeviceBuffersCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c BroadcomDeviceBuffersCounter) RecordType() int {
+	return TypeBroadcomDeviceBuffersCounterRecord
+}
+
+func (c BroadcomDeviceBuffersCounter) calculateBinarySize() int {
+	return binary.Size(c)
+}
+
+func (c BroadcomDeviceBuffersCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, c)
+}
+
+// BroadcomPortBuffersCounter - TypeBroadcomPortBuffersCounterRecord
+// Peak port level buffer utilization since the last export.
+type BroadcomPortBuffersCounter struct {
+	IngressUnicastPc        int32 /* ingress unicast buffers utilization */
+	IngressMulticastPc      int32 /* ingress multicast buffers utilization */
+	EgressUnicastPc         int32 /* egress unicast buffers utilization */
+	EgressMulticastPc       int32 /* egress multicast buffers utilization */
+	EgressQueueUnicastLen   uint32
+	EgressQueueUnicastPc    []int32 `lengthLookUp:"EgressQueueUnicastLen"` /* per egress queue unicast buffers utilization */
+	EgressQueueMulticastLen uint32
+	EgressQueueMulticastPc  []int32 `lengthLookUp:"EgressQueueMulticastLen"` /* per egress queue multicast buffers utilization */
+}
+
+func (c BroadcomPortBuffersCounter) String() string {
+	type X BroadcomPortBuffersCounter
+	x := X(c)
+	return fmt.Sprintf("BroadcomPortBuffersCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c BroadcomPortBuffersCounter) RecordName() string {
+	return "BroadcomPortBuffersCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c BroadcomPortBuffersCounter) RecordType() int {
+	return TypeBroadcomPortBuffersCounterRecord
+}
+
+func (c BroadcomPortBuffersCounter) calculateBinarySize() int {
+	var size int
+
+	size += binary.Size(c.IngressUnicastPc)
+	size += binary.Size(c.IngressMulticastPc)
+	size += binary.Size(c.EgressUnicastPc)
+	size += binary.Size(c.EgressMulticastPc)
+	size += binary.Size(c.EgressQueueUnicastLen)
+	size += binary.Size(c.EgressQueueUnicastPc)
+	size += binary.Size(c.EgressQueueMulticastLen)
+	size += binary.Size(c.EgressQueueMulticastPc)
+
+	return size
+}
+
+func (c BroadcomPortBuffersCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, c)
+}
+
+// BroadcomTablesCounter - TypeBroadcomTablesCounterRecord
+// Switch ASIC forwarding and ACL table utilization.
+type BroadcomTablesCounter struct {
+	HostEntries           uint32
+	HostEntriesMax        uint32
+	IPv4Entries           uint32
+	IPv4EntriesMax        uint32
+	IPv6Entries           uint32
+	IPv6EntriesMax        uint32
+	IPv4IPv6Entries       uint32
+	IPv4IPv6EntriesMax    uint32
+	LongIPv6Entries       uint32
+	LongIPv6EntriesMax    uint32
+	TotalRoutes           uint32
+	TotalRoutesMax        uint32
+	ECMPNextHops          uint32
+	ECMPNextHopsMax       uint32
+	MACEntries            uint32
+	MACEntriesMax         uint32
+	IPv4Neighbors         uint32
+	IPv6Neighbors         uint32
+	IPv4Routes            uint32
+	IPv6Routes            uint32
+	ACLIngressEntries     uint32
+	ACLIngressEntriesMax  uint32
+	ACLIngressCounters    uint32
+	ACLIngressCountersMax uint32
+	ACLIngressMeters      uint32
+	ACLIngressMetersMax   uint32
+	ACLIngressSlices      uint32
+	ACLIngressSlicesMax   uint32
+	ACLEgressEntries      uint32
+	ACLEgressEntriesMax   uint32
+	ACLEgressCounters     uint32
+	ACLEgressCountersMax  uint32
+	ACLEgressMeters       uint32
+	ACLEgressMetersMax    uint32
+	ACLEgressSlices       uint32
+	ACLEgressSlicesMax    uint32
+}
+
+func (c BroadcomTablesCounter) String() string {
+	type X BroadcomTablesCounter
+	x := X(c)
+	return fmt.Sprintf("BroadcomTablesCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c BroadcomTablesCounter) RecordName() string {
+	return "BroadcomTablesCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c BroadcomTablesCounter) RecordType() int {
+	return TypeBroadcomTablesCounterRecord
+}
+
+func (c BroadcomTablesCounter) calculateBinarySize() int {
+	return binary.Size(c)
+}
+
+func (c BroadcomTablesCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, c)
+}
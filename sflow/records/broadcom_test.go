@@ -0,0 +1,68 @@
+package records
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeBroadcomPortBuffersCounterRecord(t *testing.T) {
+	rec := BroadcomPortBuffersCounter{
+		IngressUnicastPc:        1250,
+		IngressMulticastPc:      BroadcomPercentageUnknown,
+		EgressUnicastPc:         10000,
+		EgressMulticastPc:       0,
+		EgressQueueUnicastLen:   3,
+		EgressQueueUnicastPc:    []int32{100, 200, 300},
+		EgressQueueMulticastLen: 2,
+		EgressQueueMulticastPc:  []int32{BroadcomPercentageUnknown, 42},
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+rec.calculateBinarySize() {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+rec.calculateBinarySize(), b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeCounter(b, TypeBroadcomPortBuffersCounterRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestEncodeDecodeBroadcomTablesCounterRecord(t *testing.T) {
+	rec := BroadcomTablesCounter{
+		HostEntries:     1024,
+		HostEntriesMax:  8192,
+		ECMPNextHops:    12,
+		ECMPNextHopsMax: 4096,
+		ACLEgressSlices: 2,
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeCounter(b, TypeBroadcomTablesCounterRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
@@ -20,48 +20,105 @@ const (
 	TypeExtendedMlpsLvpFecFlowRecord = 1011
 	TypeExtendedVlanFlowRecord       = 1012
 
+	TypeTransactionFlowRecord                    = 2000
+	TypeExtendedNFSStorageTransactionFlowRecord  = 2001
+	TypeExtendedSCSIStorageTransactionFlowRecord = 2002
+
 	TypeExtendedSocketIPv4FlowRecord      = 2100
 	TypeExtendedSocketIPv6FlowRecord      = 2101
 	TypeExtendedProxySocketIPv4FlowRecord = 2102
 	TypeExtendedProxySocketIPv6FlowRecord = 2103
+	TypeMemcacheOperationFlowRecord       = 2200
+	TypeAppOperationFlowRecord            = 2202
+	TypeAppParentContextFlowRecord        = 2203
+	TypeAppInitiatorFlowRecord            = 2204
+	TypeAppTargetFlowRecord               = 2205
 	TypeHTTPRequestFlowRecord             = 2206
 	TypeHTTPExtendedProxyFlowRecord       = 2207
+	TypeExtendedNavTimingFlowRecord       = 2208
 )
 
 // flow sample record data structure mapping
 var flowRecordTypes = map[uint32]interface{}{
-	TypeRawPacketFlowRecord:               RawPacketFlow{},
-	TypeEthernetFrameFlowRecord:           EthernetFrameFlow{},
-	TypeExtendedSwitchFlowRecord:          ExtendedSwitchFlow{},
-	TypeExtendedRouterFlowRecord:          ExtendedRouterFlow{},
-	TypeExtendedGatewayFlowRecord:         ExtendedGatewayFlow{},
-	TypeExtendedSocketIPv4FlowRecord:      ExtendedSocketIPv4Flow{},
-	TypeExtendedSocketIPv6FlowRecord:      ExtendedSocketIPv6Flow{},
-	TypeExtendedProxySocketIPv4FlowRecord: ExtendedProxySocketIPv4Flow{},
-	TypeExtendedProxySocketIPv6FlowRecord: ExtendedProxySocketIPv6Flow{},
-	TypeHTTPRequestFlowRecord:             HTTPRequestFlow{},
+	TypeRawPacketFlowRecord:                      RawPacketFlow{},
+	TypeEthernetFrameFlowRecord:                  EthernetFrameFlow{},
+	TypeExtendedSwitchFlowRecord:                 ExtendedSwitchFlow{},
+	TypeExtendedRouterFlowRecord:                 ExtendedRouterFlow{},
+	TypeExtendedGatewayFlowRecord:                ExtendedGatewayFlow{},
+	TypeTransactionFlowRecord:                    TransactionFlow{},
+	TypeExtendedNFSStorageTransactionFlowRecord:  ExtendedNFSStorageTransactionFlow{},
+	TypeExtendedSCSIStorageTransactionFlowRecord: ExtendedSCSIStorageTransactionFlow{},
+	TypeExtendedSocketIPv4FlowRecord:             ExtendedSocketIPv4Flow{},
+	TypeExtendedSocketIPv6FlowRecord:             ExtendedSocketIPv6Flow{},
+	TypeExtendedProxySocketIPv4FlowRecord:        ExtendedProxySocketIPv4Flow{},
+	TypeExtendedProxySocketIPv6FlowRecord:        ExtendedProxySocketIPv6Flow{},
+	TypeMemcacheOperationFlowRecord:              MemcacheOperationFlow{},
+	TypeAppOperationFlowRecord:                   AppOperationFlow{},
+	TypeAppParentContextFlowRecord:               AppParentContextFlow{},
+	TypeAppInitiatorFlowRecord:                   AppInitiatorFlow{},
+	TypeAppTargetFlowRecord:                      AppTargetFlow{},
+	TypeHTTPRequestFlowRecord:                    HTTPRequestFlow{},
+	TypeHTTPExtendedProxyFlowRecord:              ExtendedProxyRequestFlow{},
+	TypeExtendedNavTimingFlowRecord:              ExtendedNavTimingFlow{},
 }
 
 // sflow counter record types
 const (
 	TypeHostDescriptionCounterRecord = 2000
+	TypeJMXRuntimeCounterRecord      = 2105
+	TypeJMXStatisticsCounterRecord   = 2106
 	TypeHTTPCounterRecord            = 2201
+	TypeAppOperationsCounterRecord   = 2202
+	TypeAppResourcesCounterRecord    = 2203
+	TypeMemcacheCounterRecord        = 2204
+	TypeAppWorkersCounterRecord      = 2206
+)
+
+// sFlow enterprise numbers of vendor specific record types
+const (
+	EnterpriseBroadcom = 4413
+	EnterpriseNVIDIA   = 5703
+)
+
+// sflow enterprise counter record types
+// The data format is encoded as (enterprise << 12) + format, so these types
+// never collide with the standard (enterprise 0) formats of the same number.
+const (
+	TypeBroadcomDeviceBuffersCounterRecord = EnterpriseBroadcom<<12 + 1
+	TypeBroadcomPortBuffersCounterRecord   = EnterpriseBroadcom<<12 + 2
+	TypeBroadcomTablesCounterRecord        = EnterpriseBroadcom<<12 + 3
+
+	TypeNVIDIAGPUCounterRecord = EnterpriseNVIDIA<<12 + 1
 )
 
 // counter sample record data structure mapping
 var counterRecordTypes = map[uint32]interface{}{
-	TypeHTTPCounterRecord: HTTPCounter{},
+	TypeJMXRuntimeCounterRecord:    JMXRuntimeCounter{},
+	TypeJMXStatisticsCounterRecord: JMXStatisticsCounter{},
+	TypeHTTPCounterRecord:          HTTPCounter{},
+	TypeAppOperationsCounterRecord: AppOperationsCounter{},
+	TypeAppResourcesCounterRecord:  AppResourcesCounter{},
+	TypeMemcacheCounterRecord:      MemcacheCounter{},
+	TypeAppWorkersCounterRecord:    AppWorkersCounter{},
 	//TypeHostDescriptionCounterRecord: HostDescriptionCounter{},
 
+	TypeBroadcomDeviceBuffersCounterRecord: BroadcomDeviceBuffersCounter{},
+	TypeBroadcomPortBuffersCounterRecord:   BroadcomPortBuffersCounter{},
+	TypeBroadcomTablesCounterRecord:        BroadcomTablesCounter{},
+	TypeNVIDIAGPUCounterRecord:             NVIDIAGPUCounter{},
 }
 
 // IP Header Protocol Types (see: https://en.wikipedia.org/wiki/List_of_IP_protocol_numbers)
 const (
-	IPProtocolICMP = 1
-	IPProtocolTCP  = 6
-	IPProtocolUDP  = 17
-	IPProtocolESP  = 50 // IPSEC
-	IPProtocolAH   = 51 // IPSEC
+	IPProtocolICMP              = 1
+	IPProtocolIPIP              = 4
+	IPProtocolTCP               = 6
+	IPProtocolUDP               = 17
+	IPProtocolIPv6Encapsulation = 41
+	IPProtocolGRE               = 47
+	IPProtocolESP               = 50 // IPSEC
+	IPProtocolAH                = 51 // IPSEC
+	IPProtocolICMPv6            = 58
 )
 
 const (
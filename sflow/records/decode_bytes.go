@@ -0,0 +1,85 @@
+package records
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// rawPacketFlowPool holds the raw packet records decoded by DecodeFlowBytes along with their decoded headers
+var rawPacketFlowPool = sync.Pool{New: func() interface{} { return &RawPacketFlow{} }}
+
+// DecodeFlowBytes decodes a flow record of the given type from b, which holds the record data, and
+// returns the number of bytes of b it consumed. The record is parsed in place: its byte slices (like
+// RawPacketFlow.Header and the addresses) refer to b. Records are returned as pointers taken from a pool,
+// like *RawPacketFlow, so decoding them does not allocate. They can be handed back with ReleaseRecord.
+// Unknown record types return ErrUnknownRecordType itself rather than wrapping it with the type.
+func DecodeFlowBytes(b []byte, recordType uint32) (Record, int, error) {
+	if recordType == TypeRawPacketFlowRecord {
+		return decodeRawPacketFlowBytes(b)
+	}
+
+	if rec, n, found, err := decodeFlowXDRBytes(b, recordType); found {
+		return rec, n, err
+	}
+
+	return nil, 0, ErrUnknownRecordType
+}
+
+// DecodeCounterBytes decodes a counter record of the given type from b like DecodeFlowBytes
+func DecodeCounterBytes(b []byte, recordType uint32) (Record, int, error) {
+	if rec, n, found, err := decodeCounterXDRBytes(b, recordType); found {
+		return rec, n, err
+	}
+
+	return nil, 0, ErrUnknownRecordType
+}
+
+// ReleaseRecord hands a record decoded by DecodeFlowBytes or DecodeCounterBytes back to its pool.
+// Neither the record nor anything it refers to may be used afterwards. Other records are left alone.
+func ReleaseRecord(rec Record) {
+	if f, ok := rec.(*RawPacketFlow); ok {
+		rawPacketFlowPool.Put(f)
+		return
+	}
+
+	releaseXDRRecord(rec)
+}
+
+// decodeRawPacketFlowBytes decodes a TypeRawPacketFlowRecord like DecodeRawPacketFlow
+func decodeRawPacketFlowBytes(b []byte) (*RawPacketFlow, int, error) {
+	f := rawPacketFlowPool.Get().(*RawPacketFlow)
+
+	// The decoded header of a pooled record is reused by decodeHeader
+	p := f.DecodedHeader
+	*f = RawPacketFlow{}
+
+	if len(b) < 16 {
+		return f, len(b), io.ErrUnexpectedEOF
+	}
+
+	f.Protocol = binary.BigEndian.Uint32(b[0:])
+	f.FrameLength = binary.BigEndian.Uint32(b[4:])
+	f.Stripped = binary.BigEndian.Uint32(b[8:])
+	f.HeaderSize = binary.BigEndian.Uint32(b[12:])
+	if f.HeaderSize > MaximumHeaderLength {
+		return f, 16, fmt.Errorf("sflow: header length more than %d: %d",
+			MaximumHeaderLength, f.HeaderSize)
+	}
+
+	// The header is padded to 4 bytes
+	end := 16 + int(f.HeaderSize)
+	n := end + int((4-f.HeaderSize%4)%4)
+	if len(b) < n {
+		return f, len(b), io.ErrUnexpectedEOF
+	}
+
+	f.Header = b[16:end:end]
+
+	// Sampled headers are truncated more often than not, see DecodeRawPacketFlow
+	f.DecodedHeader = p
+	f.decodeHeader(f.Protocol)
+
+	return f, n, nil
+}
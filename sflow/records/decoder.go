@@ -19,6 +19,11 @@ func DecodeFlow(r io.Reader, recordType uint32) (Record, error) {
 	case TypeRawPacketFlowRecord:
 		return DecodeRawPacketFlow(r)
 	default:
+		// Records with a generated decoder are created without reflection
+		if rec, found, err := decodeFlowXDR(r, recordType); found {
+			return rec, err
+		}
+
 		if recordStruct, found := flowRecordTypes[recordType]; found {
 			data := reflect.New(reflect.TypeOf(recordStruct)).Elem()
 
@@ -33,7 +38,7 @@ func DecodeFlow(r io.Reader, recordType uint32) (Record, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("Flow record type %d is not implemented yet\n", recordType)
+	return nil, fmt.Errorf("flow record type %d: %w", recordType, ErrUnknownRecordType)
 }
 
 func DecodeCounter(r io.Reader, recordType uint32) (Record, error) {
@@ -41,6 +46,11 @@ func DecodeCounter(r io.Reader, recordType uint32) (Record, error) {
 
 	switch recordType {
 	default:
+		// Records with a generated decoder are created without reflection
+		if rec, found, err := decodeCounterXDR(r, recordType); found {
+			return rec, err
+		}
+
 		if recordStruct, found := counterRecordTypes[recordType]; found {
 			data := reflect.New(reflect.TypeOf(recordStruct)).Elem()
 
@@ -55,16 +65,45 @@ func DecodeCounter(r io.Reader, recordType uint32) (Record, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("Counter record type %d is not implemented yet\n", recordType)
+	return nil, fmt.Errorf("counter record type %d: %w", recordType, ErrUnknownRecordType)
+}
+
+// hasIgnoredFields returns true if the struct given by 's' has fields marked with "ignoreOnMarshal" Tags
+func hasIgnoredFields(s interface{}) bool {
+	structure := reflect.TypeOf(s)
+	if structure.Kind() == reflect.Ptr {
+		structure = structure.Elem()
+	}
+
+	if structure.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < structure.NumField(); i++ {
+		if structure.Field(i).Tag.Get("ignoreOnMarshal") == "true" {
+			return true
+		}
+	}
+	return false
 }
 
 // Decode an sflow packet read from 'r' into the struct given by 's' - The structs datatypes have to match the binary representation in the bytestream exactly
+// Structs with a generated decoder (see xdr_gen.go) are decoded by it, all others by decodeReflect.
 func decodeInto(r io.Reader, s interface{}) (int, error) {
+	if d, ok := s.(XDRDecoder); ok {
+		return d.DecodeXDR(r)
+	}
+
+	return decodeReflect(r, s)
+}
+
+// decodeReflect decodes into 's' driven by its struct tags
+func decodeReflect(r io.Reader, s interface{}) (int, error) {
 	var err error
 	var bytesRead int
 
 	// If the provided datastructure has a static size we can decode it directly
-	if size := binary.Size(s); size != -1 {
+	if size := binary.Size(s); size != -1 && !hasIgnoredFields(s) {
 		err = binary.Read(r, binary.BigEndian, s)
 		return size, err
 	}
@@ -163,6 +202,15 @@ func decodeInto(r io.Reader, s interface{}) (int, error) {
 					}
 					bufferSize := reflect.Indirect(data).FieldByName(lengthField).Uint()
 
+					// Do not allocate more than the field may hold
+					var maxLength int
+					if maxLength, err = fieldMaxLength(structure.Field(i)); err != nil {
+						return bytesRead, err
+					}
+					if bufferSize > uint64(maxLength) {
+						return bytesRead, maxLengthError(structure.Name()+"."+structure.Field(i).Name, bufferSize, maxLength)
+					}
+
 					if bufferSize > 0 {
 						switch field.Type().Elem().Kind() {
 						case reflect.Struct, reflect.Slice, reflect.Array:
@@ -170,11 +218,18 @@ func decodeInto(r io.Reader, s interface{}) (int, error) {
 							field.Set(reflect.MakeSlice(field.Type(), int(bufferSize), int(bufferSize)))
 
 							for x := 0; x < int(bufferSize); x++ {
-								decodeInto(r, field.Index(x).Addr().Interface())
+								n, err := decodeReflect(r, field.Index(x).Addr().Interface())
+								bytesRead += n
+								if err != nil {
+									return bytesRead, err
+								}
 							}
 						default:
-							//Apply padding
-							size := bufferSize + (4-(bufferSize%4))%4
+							size := bufferSize
+							if field.Type().Elem().Kind() == reflect.Uint8 {
+								//Apply padding, XDR opaque data is aligned to 4 bytes
+								size += (4 - (bufferSize % 4)) % 4
+							}
 
 							// For slices of defined length types we can look up the length and decode directly
 							field.Set(reflect.MakeSlice(field.Type(), int(size), int(size)))
@@ -184,13 +239,20 @@ func decodeInto(r io.Reader, s interface{}) (int, error) {
 								return bytesRead, err
 							}
 							bytesRead += binary.Size(field.Addr().Interface())
+
+							// The padding is consumed but not part of the value
+							field.Set(field.Slice(0, int(bufferSize)))
 						}
 					}
 				}
 			case reflect.Struct:
 				// For structs we call Decode revursively
 				field.Set(reflect.Zero(field.Type()))
-				decodeInto(r, field.Addr().Interface())
+				n, err := decodeReflect(r, field.Addr().Interface())
+				bytesRead += n
+				if err != nil {
+					return bytesRead, err
+				}
 
 			default:
 				return bytesRead, fmt.Errorf("Unhandled Field Kind: %s", field.Kind())
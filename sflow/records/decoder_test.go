@@ -15,6 +15,53 @@ func SkipHeaderBytes(r io.Reader) error {
 	return err
 }
 
+// decodeTestPacket decodes the given sampled header as a RawPacketFlow and returns the decoded packet
+func decodeTestPacket(t *testing.T, protocol uint32, header []byte) *DecodedPacket {
+	rec := RawPacketFlow{
+		Protocol:    protocol,
+		FrameLength: uint32(len(header)),
+		HeaderSize:  uint32(len(header)),
+		Header:      header,
+	}
+
+	b := &bytes.Buffer{}
+	if err := rec.Encode(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SkipHeaderBytes(b); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DecodeFlow(b, TypeRawPacketFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return decoded.(RawPacketFlow).DecodedHeader
+}
+
+// testIPv4UDPPacket is a IPv4 UDP packet (192.0.2.1:5000 -> 198.51.100.7:6000) to be encapsulated in link layer test headers
+var testIPv4UDPPacket = []byte{
+	0x45, 0x00, 0x00, 0x1c, 0x00, 0x01, 0x00, 0x00, 0x40, 0x11, 0x00, 0x00,
+	192, 0, 2, 1, 198, 51, 100, 7,
+	0x13, 0x88, 0x17, 0x70, 0x00, 0x08, 0x00, 0x00,
+}
+
+// testIPv4UDPHeader is the UDP header of testIPv4UDPPacket
+var testIPv4UDPHeader = UDPHeader{SrcPort: 5000, DstPort: 6000, Length: 8}
+
+// testIPv4Packet returns an IPv4 packet (192.0.2.1 -> 198.51.100.7) carrying the given layer 4 protocol and payload
+func testIPv4Packet(protocol uint8, payload []byte) []byte {
+	length := 20 + len(payload)
+
+	header := []byte{
+		0x45, 0x00, uint8(length >> 8), uint8(length), 0x00, 0x01, 0x00, 0x00, 0x40, protocol, 0x00, 0x00,
+		192, 0, 2, 1, 198, 51, 100, 7,
+	}
+	return append(header, payload...)
+}
+
 func TestDecodeGenericRecordStatic(t *testing.T) {
 	var binaryData []byte
 
@@ -27,7 +74,7 @@ func TestDecodeGenericRecordStatic(t *testing.T) {
 
 	buffer := bytes.NewBuffer(binaryData)
 	binary.Write(buffer, binary.BigEndian, &testFlow)
-	resultRecord, err := Decode(buffer, TypeExtendedSwitchFlowRecord)
+	resultRecord, err := DecodeFlow(buffer, TypeExtendedSwitchFlowRecord)
 	if err != nil {
 		t.Fatalf("Error: %s\n", err)
 	}
@@ -52,7 +99,7 @@ func TestDecodeGenericRecordDynamic(t *testing.T) {
 	testFlow.Encode(buffer)
 
 	SkipHeaderBytes(buffer)
-	resultRecord, err := Decode(buffer, TypeExtendedRouterFlowRecord)
+	resultRecord, err := DecodeFlow(buffer, TypeExtendedRouterFlowRecord)
 	if err != nil {
 		t.Fatalf("Error: %s\n", err)
 	}
@@ -61,3 +108,33 @@ func TestDecodeGenericRecordDynamic(t *testing.T) {
 		t.Errorf("expected\n%+#v\n, got\n%+#v", testFlow, resultRecord)
 	}
 }
+
+type testDecodeItem struct {
+	Length uint32
+	Data   []uint8 `lengthLookUp:"Length"`
+}
+
+type testDecodeList struct {
+	Count uint32
+	Items []testDecodeItem `lengthLookUp:"Count"`
+}
+
+func TestDecodeNestedError(t *testing.T) {
+	data := []byte{
+		0x00, 0x00, 0x00, 0x02,
+		// First item
+		0x00, 0x00, 0x00, 0x02, 0xab, 0xcd, 0x00, 0x00,
+		// Second item, the data is missing
+		0x00, 0x00, 0x00, 0x04,
+	}
+
+	list := testDecodeList{}
+	n, err := decodeInto(bytes.NewReader(data), &list)
+
+	if err != io.ErrUnexpectedEOF && err != io.EOF {
+		t.Errorf("expected the error of the second item, got %v", err)
+	}
+	if n != len(data) {
+		t.Errorf("expected %d bytes read, got %d", len(data), n)
+	}
+}
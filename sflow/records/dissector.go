@@ -0,0 +1,99 @@
+package records
+
+import (
+	"bytes"
+)
+
+// Dissector decodes the header or payload following an EtherType, IP protocol number or
+// TCP/UDP port from h into p. Dissectors store their results in the layers of p, or with
+// p.SetLayer if there is no field for them.
+type Dissector func(h *bytes.Reader, p *DecodedPacket) error
+
+type portKey struct {
+	protocol uint8
+	port     uint16
+}
+
+var etherTypeDissectors = map[uint16]Dissector{}
+var ipProtocolDissectors = map[uint8]Dissector{}
+var portDissectors = map[portKey]Dissector{}
+
+// RegisterEtherTypeDissector registers the dissector for the header following the given EtherType.
+// It replaces the dissector registered before, including the built-in ones.
+// Dissectors must be registered before decoding starts.
+func RegisterEtherTypeDissector(etherType uint16, d Dissector) {
+	etherTypeDissectors[etherType] = d
+}
+
+// RegisterIPProtocolDissector registers the dissector for the header following an IPv4 or IPv6 header
+// with the given protocol number. It replaces the dissector registered before, including the built-in ones.
+// Dissectors must be registered before decoding starts.
+func RegisterIPProtocolDissector(protocol uint8, d Dissector) {
+	ipProtocolDissectors[protocol] = d
+}
+
+// RegisterPortDissector registers the dissector for the payload of TCP or UDP (protocol IPProtocolTCP or
+// IPProtocolUDP) packets from or to the given port. The TCP or UDP header is available in p.Transport.
+// Port dissectors only run if DecodeApplicationLayer is enabled. It replaces the dissector registered
+// before, including the built-in ones. Dissectors must be registered before decoding starts.
+func RegisterPortDissector(protocol uint8, port uint16, d Dissector) {
+	portDissectors[portKey{protocol, port}] = d
+}
+
+func init() {
+	RegisterEtherTypeDissector(HeaderTypeIPv4, func(h *bytes.Reader, p *DecodedPacket) error {
+		return decodeIPHeader(4, h, p)
+	})
+	RegisterEtherTypeDissector(HeaderTypeIPv6, func(h *bytes.Reader, p *DecodedPacket) error {
+		return decodeIPHeader(6, h, p)
+	})
+	RegisterEtherTypeDissector(HeaderTypeVLAN, dissectVLANTag)
+	RegisterEtherTypeDissector(HeaderTypeQinQ, dissectVLANTag)
+	RegisterEtherTypeDissector(HeaderTypeARP, dissectARP)
+	RegisterEtherTypeDissector(HeaderTypeLLDP, dissectLLDP)
+	RegisterEtherTypeDissector(HeaderTypeIPX, dissectIPX)
+	RegisterEtherTypeDissector(HeaderTypeTransparentEthernetBridging, decodeEthernet)
+
+	RegisterIPProtocolDissector(IPProtocolTCP, dissectTCP)
+	RegisterIPProtocolDissector(IPProtocolUDP, dissectUDP)
+	RegisterIPProtocolDissector(IPProtocolICMP, func(h *bytes.Reader, p *DecodedPacket) error {
+		icmp, err := decodeICMPHeader(h, 4, p.embedded)
+		p.transport().ICMP = &icmp
+		return err
+	})
+	RegisterIPProtocolDissector(IPProtocolICMPv6, func(h *bytes.Reader, p *DecodedPacket) error {
+		icmp, err := decodeICMPHeader(h, 6, p.embedded)
+		p.transport().ICMPv6 = &icmp
+		return err
+	})
+	RegisterIPProtocolDissector(IPProtocolIPIP, func(h *bytes.Reader, p *DecodedPacket) error {
+		p.Inner = p.encapsulated()
+		return decodeIPHeader(4, h, p.Inner)
+	})
+	RegisterIPProtocolDissector(IPProtocolIPv6Encapsulation, func(h *bytes.Reader, p *DecodedPacket) error {
+		p.Inner = p.encapsulated()
+		return decodeIPHeader(6, h, p.Inner)
+	})
+	RegisterIPProtocolDissector(IPProtocolGRE, dissectGRE)
+	// No use in decoding ipsec headers
+	RegisterIPProtocolDissector(IPProtocolESP, skipDissector)
+	RegisterIPProtocolDissector(IPProtocolAH, skipDissector)
+
+	RegisterPortDissector(IPProtocolUDP, DNSPort, dissectDNS)
+	RegisterPortDissector(IPProtocolTCP, DNSPort, dissectDNS)
+	RegisterPortDissector(IPProtocolTCP, TLSPort, dissectTLSClientHello)
+}
+
+// skipDissector leaves the following header undecoded
+func skipDissector(h *bytes.Reader, p *DecodedPacket) error {
+	return nil
+}
+
+// portDissector returns the dissector registered for the destination or else the source port
+func portDissector(protocol uint8, srcPort, dstPort uint16) (Dissector, bool) {
+	if d, found := portDissectors[portKey{protocol, dstPort}]; found {
+		return d, true
+	}
+	d, found := portDissectors[portKey{protocol, srcPort}]
+	return d, found
+}
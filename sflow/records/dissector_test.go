@@ -0,0 +1,94 @@
+package records
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type testTelemetryHeader struct {
+	Version  uint8  `json:"version"`
+	Sequence uint16 `json:"sequence"`
+}
+
+func (h testTelemetryHeader) LayerName() string {
+	return "telemetry"
+}
+
+// testFirstByte is the first byte of a header
+type testFirstByte uint8
+
+func (b testFirstByte) LayerName() string {
+	return "first"
+}
+
+func TestRegisterPortDissector(t *testing.T) {
+	testApplicationLayer(t)
+
+	RegisterPortDissector(IPProtocolUDP, 6000, func(h *bytes.Reader, p *DecodedPacket) error {
+		telemetry := testTelemetryHeader{}
+		err := decodeTruncated(h, &telemetry)
+		p.SetLayer(telemetry)
+		return err
+	})
+	defer delete(portDissectors, portKey{IPProtocolUDP, 6000})
+
+	payload := []byte{
+		// UDP 5000 -> 6000
+		0x13, 0x88, 0x17, 0x70, 0x00, 0x0b, 0x00, 0x00,
+		// Version 2, sequence 258
+		0x02, 0x01, 0x02,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4Packet(IPProtocolUDP, payload))
+
+	expected := testTelemetryHeader{Version: 2, Sequence: 258}
+	if telemetry := p.Layers["telemetry"]; !reflect.DeepEqual(telemetry, expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, telemetry)
+	}
+	if p.transport().UDP == nil {
+		t.Errorf("expected the UDP header to be decoded, got %+v", p)
+	}
+}
+
+func TestRegisterEtherTypeDissector(t *testing.T) {
+	// Replace the built-in ARP dissector
+	RegisterEtherTypeDissector(HeaderTypeARP, func(h *bytes.Reader, p *DecodedPacket) error {
+		b, err := h.ReadByte()
+		p.SetLayer(testFirstByte(b))
+		return err
+	})
+	defer RegisterEtherTypeDissector(HeaderTypeARP, dissectARP)
+
+	header := []byte{
+		// Ethernet
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x08, 0x06,
+		0x00, 0x01, 0x08, 0x00,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolEthernetISO8023, header)
+
+	if first := p.Layers["first"]; first != testFirstByte(0) {
+		t.Errorf("expected the registered dissector to run, got %+v", p)
+	}
+	if p.Network != nil {
+		t.Errorf("expected no built-in ARP decoding, got %+v", p.Network)
+	}
+	if p.Offset != MinimumEthernetHeaderSize+1 {
+		t.Errorf("expected offset %d, got %d", MinimumEthernetHeaderSize+1, p.Offset)
+	}
+}
+
+func TestRegisterIPProtocolDissectorError(t *testing.T) {
+	RegisterIPProtocolDissector(IPProtocolUDP, func(h *bytes.Reader, p *DecodedPacket) error {
+		return io.ErrUnexpectedEOF
+	})
+	defer RegisterIPProtocolDissector(IPProtocolUDP, dissectUDP)
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4UDPPacket)
+
+	if !reflect.DeepEqual(p.Errors, []string{io.ErrUnexpectedEOF.Error()}) {
+		t.Errorf("expected the dissector error to be recorded, got %v", p.Errors)
+	}
+}
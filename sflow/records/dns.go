@@ -0,0 +1,189 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// DNSPort is the well-known port of DNS over UDP and TCP
+const DNSPort = 53
+
+// DNS Response Codes
+const (
+	DNSRCodeNoError  = 0
+	DNSRCodeFormErr  = 1
+	DNSRCodeServFail = 2
+	DNSRCodeNXDomain = 3
+	DNSRCodeNotImp   = 4
+	DNSRCodeRefused  = 5
+)
+
+var dnsRCodeNames = map[uint32]string{
+	DNSRCodeNoError:  "NOERROR",
+	DNSRCodeFormErr:  "FORMERR",
+	DNSRCodeServFail: "SERVFAIL",
+	DNSRCodeNXDomain: "NXDOMAIN",
+	DNSRCodeNotImp:   "NOTIMP",
+	DNSRCodeRefused:  "REFUSED",
+}
+
+// DNS Query Types
+const (
+	DNSTypeA     = 1
+	DNSTypeNS    = 2
+	DNSTypeCNAME = 5
+	DNSTypeSOA   = 6
+	DNSTypePTR   = 12
+	DNSTypeMX    = 15
+	DNSTypeTXT   = 16
+	DNSTypeAAAA  = 28
+	DNSTypeSRV   = 33
+	DNSTypeDS    = 43
+	DNSTypeHTTPS = 65
+	DNSTypeANY   = 255
+)
+
+var dnsTypeNames = map[uint32]string{
+	DNSTypeA:     "A",
+	DNSTypeNS:    "NS",
+	DNSTypeCNAME: "CNAME",
+	DNSTypeSOA:   "SOA",
+	DNSTypePTR:   "PTR",
+	DNSTypeMX:    "MX",
+	DNSTypeTXT:   "TXT",
+	DNSTypeAAAA:  "AAAA",
+	DNSTypeSRV:   "SRV",
+	DNSTypeDS:    "DS",
+	DNSTypeHTTPS: "HTTPS",
+	DNSTypeANY:   "ANY",
+}
+
+// DNSHeaderSize is the size of the fixed part of a DNS message
+const DNSHeaderSize = 12
+
+// DNSHeader is the header and first question of a DNS message as found in RawPacketFlow.Header
+// QueryName holds the labels read so far if the sampled header ends within the question.
+type DNSHeader struct {
+	ID            uint16 `json:"id"`
+	Flags         uint16 `json:"flags"`
+	QDCount       uint16 `json:"qdCount"`
+	ANCount       uint16 `json:"anCount"`
+	NSCount       uint16 `json:"nsCount"`
+	ARCount       uint16 `json:"arCount"`
+	Response      bool   `json:"response"`
+	Opcode        uint8  `json:"opcode"`
+	RCode         uint8  `json:"rCode"`
+	RCodeName     string `json:"rCodeName"`
+	QueryName     string `json:"queryName,omitempty"`
+	QueryType     uint16 `json:"queryType,omitempty"`
+	QueryTypeName string `json:"queryTypeName,omitempty"`
+}
+
+// decodeDNS decodes a DNS message. It returns false if b does not contain a DNS header and an error
+// if the query name is malformed.
+func decodeDNS(b []byte) (DNSHeader, bool, error) {
+	dns := DNSHeader{}
+
+	if len(b) < DNSHeaderSize {
+		return dns, false, nil
+	}
+
+	dns.ID = binary.BigEndian.Uint16(b[0:2])
+	dns.Flags = binary.BigEndian.Uint16(b[2:4])
+	dns.QDCount = binary.BigEndian.Uint16(b[4:6])
+	dns.ANCount = binary.BigEndian.Uint16(b[6:8])
+	dns.NSCount = binary.BigEndian.Uint16(b[8:10])
+	dns.ARCount = binary.BigEndian.Uint16(b[10:12])
+
+	dns.Response = dns.Flags&0x8000 != 0
+	dns.Opcode = uint8(dns.Flags>>11) & 0x0f
+	dns.RCode = uint8(dns.Flags & 0x000f)
+	dns.RCodeName = lookupName(dnsRCodeNames, uint32(dns.RCode))
+
+	if dns.QDCount == 0 {
+		return dns, true, nil
+	}
+
+	name, offset, ok, err := dnsName(b, DNSHeaderSize)
+	dns.QueryName = name
+	if err != nil {
+		return dns, true, err
+	}
+
+	if ok && offset+2 <= len(b) {
+		dns.QueryType = binary.BigEndian.Uint16(b[offset : offset+2])
+		dns.QueryTypeName = lookupName(dnsTypeNames, uint32(dns.QueryType))
+	}
+
+	return dns, true, nil
+}
+
+// dnsName reads the domain name starting at offset in the DNS message b. It returns the name,
+// the offset following it and false if the name is truncated or malformed. Labels of the reserved
+// types 0x40 and 0x80 are an error.
+func dnsName(b []byte, offset int) (string, int, bool, error) {
+	var labels []string
+	next := -1
+
+	// Every compression pointer has to point backwards, which bounds the number of jumps
+	for jumps := 0; jumps < len(b); jumps++ {
+		if offset >= len(b) {
+			return strings.Join(labels, "."), offset, false, nil
+		}
+
+		length := int(b[offset])
+		switch {
+		case length == 0:
+			if next == -1 {
+				next = offset + 1
+			}
+			return strings.Join(labels, ".") + ".", next, true, nil
+		case length&0xc0 == 0x40, length&0xc0 == 0x80:
+			err := fmt.Errorf("%w: reserved DNS label type %#x at offset %d", ErrDecodingRecord, length&0xc0, offset)
+			return strings.Join(labels, "."), offset, false, err
+		case length&0xc0 == 0xc0:
+			if offset+2 > len(b) {
+				return strings.Join(labels, "."), offset, false, nil
+			}
+
+			pointer := int(binary.BigEndian.Uint16(b[offset:offset+2]) & 0x3fff)
+			if pointer >= offset {
+				return strings.Join(labels, "."), offset, false, nil
+			}
+			if next == -1 {
+				next = offset + 2
+			}
+			offset = pointer
+		default:
+			if offset+1+length > len(b) {
+				return strings.Join(labels, "."), offset, false, nil
+			}
+
+			labels = append(labels, string(b[offset+1:offset+1+length]))
+			offset += 1 + length
+		}
+	}
+
+	return strings.Join(labels, "."), offset, false, nil
+}
+
+// dissectDNS decodes a DNS message carried by TCP or UDP from h into p
+func dissectDNS(h *bytes.Reader, p *DecodedPacket) error {
+	payload := readPayload(h)
+
+	// DNS over TCP prefixes messages with their length
+	if p.Transport != nil && p.Transport.TCP != nil {
+		if len(payload) < 2 {
+			return nil
+		}
+		payload = payload[2:]
+	}
+
+	dns, ok, err := decodeDNS(payload)
+	if ok {
+		p.application().DNS = &dns
+	}
+	return err
+}
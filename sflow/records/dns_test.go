@@ -0,0 +1,100 @@
+package records
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeDNSQuery(t *testing.T) {
+	testApplicationLayer(t)
+
+	payload := []byte{
+		// UDP 40000 -> 53
+		0x9c, 0x40, 0x00, 0x35, 0x00, 0x29, 0x00, 0x00,
+		// ID, flags (RD), 1 question
+		0x12, 0x34, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// www.example.com AAAA IN
+		0x03, 'w', 'w', 'w', 0x07, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 0x03, 'c', 'o', 'm', 0x00,
+		0x00, 0x1c, 0x00, 0x01,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4Packet(IPProtocolUDP, payload))
+
+	expected := DNSHeader{
+		ID:            0x1234,
+		Flags:         0x0100,
+		QDCount:       1,
+		RCodeName:     "NOERROR",
+		QueryName:     "www.example.com.",
+		QueryType:     DNSTypeAAAA,
+		QueryTypeName: "AAAA",
+	}
+
+	if dns := p.application().DNS; !reflect.DeepEqual(dns, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, dns)
+	}
+}
+
+func TestDecodeDNSResponseOverTCP(t *testing.T) {
+	testApplicationLayer(t)
+
+	payload := []byte{
+		// TCP 53 -> 40000, PSH+ACK
+		0x00, 0x35, 0x9c, 0x40, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+		0x50, 0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00,
+		// Length, ID, flags (QR, RD, RA, NXDOMAIN), 1 question
+		0x00, 0x21,
+		0x12, 0x34, 0x81, 0x83, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+		// The sample ends within the question
+		0x07, 'i', 'n', 'v', 'a', 'l', 'i', 'd', 0x07, 'e', 'x', 'a',
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4Packet(IPProtocolTCP, payload))
+
+	dns := p.application().DNS
+	if dns == nil {
+		t.Fatalf("expected a DNS header, got %+v", p)
+	}
+
+	if !dns.Response || dns.RCodeName != "NXDOMAIN" || dns.QueryName != "invalid" || dns.QueryType != 0 {
+		t.Errorf("unexpected DNS header %+v", dns)
+	}
+}
+
+func TestDNSNameCompression(t *testing.T) {
+	message := []byte{
+		0x12, 0x34, 0x81, 0x80, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+		0x07, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 0x03, 'c', 'o', 'm', 0x00,
+		// www and a pointer to example.com
+		0x03, 'w', 'w', 'w', 0xc0, 0x0c,
+		// A pointer to itself
+		0xc0, 0x1f,
+	}
+
+	if name, offset, ok, _ := dnsName(message, 25); !ok || name != "www.example.com." || offset != 31 {
+		t.Errorf("unexpected name %q (offset %d, ok %v)", name, offset, ok)
+	}
+
+	if _, _, ok, _ := dnsName(message, 31); ok {
+		t.Errorf("expected a pointer loop to be rejected")
+	}
+}
+
+func TestDNSNameReservedLabelType(t *testing.T) {
+	message := []byte{
+		0x12, 0x34, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// www and an extended label (0x40)
+		0x03, 'w', 'w', 'w', 0x41, 'x', 0x00,
+	}
+
+	name, _, ok, err := dnsName(message, 12)
+	if ok || name != "www" || !errors.Is(err, ErrDecodingRecord) {
+		t.Errorf("expected the reserved label type to be rejected, got %q (ok %v, err %v)", name, ok, err)
+	}
+
+	message[16] = 0x81
+	if _, _, ok, err := dnsName(message, 12); ok || !errors.Is(err, ErrDecodingRecord) {
+		t.Errorf("expected the reserved label type to be rejected, got ok %v, err %v", ok, err)
+	}
+}
@@ -0,0 +1,79 @@
+package records
+
+// Differentiated Services Codepoints (see https://www.iana.org/assignments/dscp-registry)
+const (
+	DSCPDefault = 0
+	DSCPLE      = 1
+	DSCPCS1     = 8
+	DSCPAF11    = 10
+	DSCPAF12    = 12
+	DSCPAF13    = 14
+	DSCPCS2     = 16
+	DSCPAF21    = 18
+	DSCPAF22    = 20
+	DSCPAF23    = 22
+	DSCPCS3     = 24
+	DSCPAF31    = 26
+	DSCPAF32    = 28
+	DSCPAF33    = 30
+	DSCPCS4     = 32
+	DSCPAF41    = 34
+	DSCPAF42    = 36
+	DSCPAF43    = 38
+	DSCPCS5     = 40
+	DSCPVA      = 44
+	DSCPEF      = 46
+	DSCPCS6     = 48
+	DSCPCS7     = 56
+)
+
+var dscpNames = map[uint32]string{
+	DSCPDefault: "DF",
+	DSCPLE:      "LE",
+	DSCPCS1:     "CS1",
+	DSCPAF11:    "AF11",
+	DSCPAF12:    "AF12",
+	DSCPAF13:    "AF13",
+	DSCPCS2:     "CS2",
+	DSCPAF21:    "AF21",
+	DSCPAF22:    "AF22",
+	DSCPAF23:    "AF23",
+	DSCPCS3:     "CS3",
+	DSCPAF31:    "AF31",
+	DSCPAF32:    "AF32",
+	DSCPAF33:    "AF33",
+	DSCPCS4:     "CS4",
+	DSCPAF41:    "AF41",
+	DSCPAF42:    "AF42",
+	DSCPAF43:    "AF43",
+	DSCPCS5:     "CS5",
+	DSCPVA:      "VOICE-ADMIT",
+	DSCPEF:      "EF",
+	DSCPCS6:     "CS6",
+	DSCPCS7:     "CS7",
+}
+
+// DSCPName returns the name of the per-hop behaviour selected by a DSCP
+func DSCPName(dscp uint8) string {
+	return lookupName(dscpNames, uint32(dscp))
+}
+
+// Explicit Congestion Notification Codepoints (see RFC 3168)
+const (
+	ECNNotECT = 0
+	ECNECT1   = 1
+	ECNECT0   = 2
+	ECNCE     = 3
+)
+
+var ecnNames = map[uint32]string{
+	ECNNotECT: "Not-ECT",
+	ECNECT1:   "ECT(1)",
+	ECNECT0:   "ECT(0)",
+	ECNCE:     "CE",
+}
+
+// ECNName returns the name of an ECN codepoint
+func ECNName(ecn uint8) string {
+	return lookupName(ecnNames, uint32(ecn))
+}
@@ -0,0 +1,52 @@
+package records
+
+import (
+	"testing"
+)
+
+func TestDecodeIPv4DSCP(t *testing.T) {
+	header := []byte{
+		// IPv4 192.0.2.1 -> 198.51.100.7, TOS 0xb9 (EF, ECT(1)), UDP
+		0x45, 0xb9, 0x00, 0x1c, 0x00, 0x01, 0x00, 0x00, 0x40, 0x11, 0x00, 0x00,
+		192, 0, 2, 1, 198, 51, 100, 7,
+		// UDP 5004 -> 5004
+		0x13, 0x8c, 0x13, 0x8c, 0x00, 0x08, 0x00, 0x00,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, header)
+
+	ip := *p.network().IPv4
+	if ip.DSCP != DSCPEF || ip.DSCPName != "EF" || ip.ECN != ECNECT1 || ip.ECNName != "ECT(1)" {
+		t.Errorf("unexpected DSCP %d (%s) and ECN %d (%s)", ip.DSCP, ip.DSCPName, ip.ECN, ip.ECNName)
+	}
+}
+
+func TestDecodeIPv6TrafficClassAndFlowLabel(t *testing.T) {
+	header := []byte{
+		// IPv6 2001:db8::1 -> 2001:db8::2, traffic class 0x8b (AF41, CE), flow label 0xabcde, UDP
+		0x68, 0xba, 0xbc, 0xde, 0x00, 0x08, 0x11, 0x40,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2,
+		// UDP 5004 -> 5004
+		0x13, 0x8c, 0x13, 0x8c, 0x00, 0x08, 0x00, 0x00,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv6, header)
+
+	ip := *p.network().IPv6
+	if ip.DSCP != DSCPAF41 || ip.DSCPName != "AF41" || ip.ECN != ECNCE || ip.ECNName != "CE" {
+		t.Errorf("unexpected DSCP %d (%s) and ECN %d (%s)", ip.DSCP, ip.DSCPName, ip.ECN, ip.ECNName)
+	}
+	if ip.FlowLabel != 0xabcde {
+		t.Errorf("expected flow label 0xabcde, got %#x", ip.FlowLabel)
+	}
+}
+
+func TestDSCPName(t *testing.T) {
+	if name := DSCPName(DSCPDefault); name != "DF" {
+		t.Errorf("expected DF, got %s", name)
+	}
+	if name := DSCPName(63); name != "UNKNOWN(63)" {
+		t.Errorf("expected UNKNOWN(63), got %s", name)
+	}
+}
@@ -7,8 +7,24 @@ import (
 	"reflect"
 )
 
+// paddedLength returns the encoded length of n bytes of XDR opaque data or string,
+// which is padded to a multiple of 4 bytes
+func paddedLength(n int) int {
+	return n + (4-(n%4))%4
+}
+
 // Encode an sflow packet from 's' into 'w' - The structs datatypes define the binary representation
+// Structs with a generated encoder (see xdr_gen.go) are encoded by it, all others by encodeReflect.
 func Encode(w io.Writer, s interface{}) error {
+	if e, ok := s.(XDREncoder); ok {
+		return e.EncodeXDR(w)
+	}
+
+	return encodeReflect(w, s)
+}
+
+// encodeReflect encodes 's' driven by its struct tags
+func encodeReflect(w io.Writer, s interface{}) error {
 	var err error
 
 	structure := reflect.TypeOf(s)
@@ -16,6 +32,15 @@ func Encode(w io.Writer, s interface{}) error {
 
 	//fmt.Printf("Encoding %+#v\n", s)
 
+	// The length fields are written from the slices they hold the length of
+	lengths := map[string]uint64{}
+	for i := 0; i < structure.NumField(); i++ {
+		field := structure.Field(i)
+		if lookup := field.Tag.Get("lengthLookUp"); lookup != "" && field.Tag.Get("ignoreOnMarshal") != "true" {
+			lengths[lookup] = uint64(data.Field(i).Len())
+		}
+	}
+
 	for i := 0; i < structure.NumField(); i++ {
 		field := structure.Field(i)
 
@@ -24,17 +49,35 @@ func Encode(w io.Writer, s interface{}) error {
 			continue
 		}
 
+		value := data.FieldByIndex(field.Index)
+		length, isLength := lengths[field.Name]
+
 		switch field.Type.Kind() {
-		case reflect.Uint8:
-			if err = binary.Write(w, binary.BigEndian, uint8(data.FieldByIndex(field.Index).Uint())); err != nil {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n := value.Uint()
+			if isLength {
+				n = length
+			}
+
+			switch field.Type.Kind() {
+			case reflect.Uint8:
+				err = binary.Write(w, binary.BigEndian, uint8(n))
+			case reflect.Uint16:
+				err = binary.Write(w, binary.BigEndian, uint16(n))
+			case reflect.Uint32:
+				err = binary.Write(w, binary.BigEndian, uint32(n))
+			default:
+				err = binary.Write(w, binary.BigEndian, n)
+			}
+			if err != nil {
 				return err
 			}
-		case reflect.Uint32:
-			if err = binary.Write(w, binary.BigEndian, uint32(data.FieldByIndex(field.Index).Uint())); err != nil {
+		case reflect.Int32:
+			if err = binary.Write(w, binary.BigEndian, int32(data.FieldByIndex(field.Index).Int())); err != nil {
 				return err
 			}
-		case reflect.Uint64:
-			if err = binary.Write(w, binary.BigEndian, uint64(data.FieldByIndex(field.Index).Uint())); err != nil {
+		case reflect.Array:
+			if err = binary.Write(w, binary.BigEndian, value.Interface()); err != nil {
 				return err
 			}
 		case reflect.Slice:
@@ -76,19 +119,49 @@ func Encode(w io.Writer, s interface{}) error {
 						return err
 					}
 				}
+			case "HardwareAddr":
+				if err = binary.Write(w, binary.BigEndian, data.FieldByIndex(field.Index).Bytes()); err != nil {
+					return err
+				}
 			default:
+				var maxLength int
+				if maxLength, err = fieldMaxLength(field); err != nil {
+					return err
+				}
+				if n := data.FieldByIndex(field.Index).Len(); n > maxLength {
+					return maxLengthError(structure.Name()+"."+field.Name, uint64(n), maxLength)
+				}
+
+				if field.Type.Elem().Kind() == reflect.Uint8 {
+					// Variable length opaque data and strings are padded to a multiple of 4 bytes
+					buffer := data.FieldByIndex(field.Index).Bytes()
+					if err = binary.Write(w, binary.BigEndian, buffer); err != nil {
+						return err
+					}
+					if err = binary.Write(w, binary.BigEndian, make([]byte, paddedLength(len(buffer))-len(buffer))); err != nil {
+						return err
+					}
+					continue
+				}
+
 				switch reflect.SliceOf(field.Type).Elem().String() {
-				case "[]uint32":
+				case "[]uint32", "[]int32":
 					// Write directly to io
 					if err = binary.Write(w, binary.BigEndian, data.FieldByIndex(field.Index).Interface()); err != nil {
 						return err
 					}
 				default:
 					for x := 0; x < data.FieldByIndex(field.Index).Len(); x++ {
-						Encode(w, data.FieldByIndex(field.Index).Index(x).Interface())
+						if err = encodeReflect(w, data.FieldByIndex(field.Index).Index(x).Interface()); err != nil {
+							return err
+						}
 					}
 				}
 			}
+		case reflect.Struct:
+			if err = encodeReflect(w, data.FieldByIndex(field.Index).Interface()); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("Unhandled Field Kind: %s", field.Type.Kind())
 		}
@@ -73,7 +73,7 @@ func (f ExtendedGatewayFlow) calculateBinarySize() int {
 
 func (f *ExtendedGatewayFlow) PostDecode() error {
 	for _, asSegment := range f.DstAsPathSegments {
-		if asSegment.SegType == AsPathSegmentTypeOrdered {
+		if asSegment.SegType == AsPathSegmentTypeOrdered && len(asSegment.Seg) > 0 {
 			// If the AS Segment is ordered then the last Element is the DstAs and the first the DstPeerAs
 			f.DstAs = asSegment.Seg[len(asSegment.Seg)-1:][0]
 			f.DstPeerAs = asSegment.Seg[0:1][0]
@@ -88,8 +88,7 @@ func (f ExtendedGatewayFlow) Encode(w io.Writer) error {
 
 	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
 	if err != nil {
-		fmt.Printf("error: %s", err)
-		return err
+		return fmt.Errorf("sflow: encoding %s: %w", f.RecordName(), err)
 	}
 
 	// Calculate Total Record Length
@@ -97,10 +96,13 @@ func (f ExtendedGatewayFlow) Encode(w io.Writer) error {
 
 	err = binary.Write(w, binary.BigEndian, uint32(encodedRecordLength))
 	if err != nil {
-		return err
+		return fmt.Errorf("sflow: encoding %s: %w", f.RecordName(), err)
 	}
 
 	err = Encode(w, f)
+	if err != nil {
+		return fmt.Errorf("sflow: encoding %s: %w", f.RecordName(), err)
+	}
 
-	return err
+	return nil
 }
@@ -2,6 +2,7 @@ package records
 
 import (
 	"bytes"
+	"errors"
 	"net"
 	"reflect"
 	"testing"
@@ -66,7 +67,7 @@ func TestEncodeDecodeExtendedGatewayFlowRecord(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	decoded, err := Decode(b, TypeExtendedGatewayFlowRecord)
+	decoded, err := DecodeFlow(b, TypeExtendedGatewayFlowRecord)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -75,3 +76,55 @@ func TestEncodeDecodeExtendedGatewayFlowRecord(t *testing.T) {
 		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
 	}
 }
+
+func TestDecodeExtendedGatewayFlowEmptyOrderedSegment(t *testing.T) {
+	rec := ExtendedGatewayFlow{
+		NextHopType:          2,
+		NextHop:              net.ParseIP("2001:0db8:ac10:fe01::"), //IPv4 fails with the DeepEqual
+		As:                   1234,
+		SrcAs:                4321,
+		SrcPeerAs:            5678,
+		DstAsPathSegmentsLen: 1,
+		DstAsPathSegments: []ExtendedGatewayFlowASPathSegment{{
+			SegType: AsPathSegmentTypeOrdered,
+			SegLen:  0,
+		}},
+		LocalPref: 255,
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Skip the header section. It's 8 bytes.
+	var headerBytes [8]byte
+
+	_, err = b.Read(headerBytes[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DecodeFlow(b, TypeExtendedGatewayFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without elements the segment has no destination AS
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestEncodeExtendedGatewayFlowError(t *testing.T) {
+	rec := ExtendedGatewayFlow{NextHopType: 1, NextHop: net.IPv4(192, 0, 2, 1).To4()}
+
+	// Fail writing the record type, the length and the fields
+	for _, n := range []int{0, 4, 8} {
+		if err := rec.Encode(&testFailingWriter{n}); !errors.Is(err, errTestWrite) {
+			t.Errorf("%d bytes: expected %v, got %v", n, errTestWrite, err)
+		}
+	}
+}
@@ -46,8 +46,7 @@ func (f ExtendedRouterFlow) Encode(w io.Writer) error {
 
 	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
 	if err != nil {
-		fmt.Printf("error: %s", err)
-		return err
+		return fmt.Errorf("sflow: encoding %s: %w", f.RecordName(), err)
 	}
 
 	// Calculate Total Record Length
@@ -55,10 +54,13 @@ func (f ExtendedRouterFlow) Encode(w io.Writer) error {
 
 	err = binary.Write(w, binary.BigEndian, uint32(encodedRecordLength))
 	if err != nil {
-		return err
+		return fmt.Errorf("sflow: encoding %s: %w", f.RecordName(), err)
 	}
 
 	err = Encode(w, f)
+	if err != nil {
+		return fmt.Errorf("sflow: encoding %s: %w", f.RecordName(), err)
+	}
 
-	return err
+	return nil
 }
@@ -1,6 +1,7 @@
 package records
 
 import (
+	"errors"
 	"net"
 	"testing"
 )
@@ -18,3 +19,29 @@ func TestCalculateBinarySizeExtendedRouterFlow(t *testing.T) {
 		t.Errorf("expected\n%+#v\n, got\n%+#v", 76, size)
 	}
 }
+
+var errTestWrite = errors.New("test write error")
+
+// testFailingWriter accepts n bytes and fails every write after them
+type testFailingWriter struct {
+	n int
+}
+
+func (w *testFailingWriter) Write(b []byte) (int, error) {
+	if len(b) > w.n {
+		return 0, errTestWrite
+	}
+	w.n -= len(b)
+	return len(b), nil
+}
+
+func TestEncodeExtendedRouterFlowError(t *testing.T) {
+	rec := ExtendedRouterFlow{NextHopType: 1, NextHop: net.IPv4(192, 0, 2, 1).To4()}
+
+	// Fail writing the record type, the length and the fields
+	for _, n := range []int{0, 4, 8} {
+		if err := rec.Encode(&testFailingWriter{n}); !errors.Is(err, errTestWrite) {
+			t.Errorf("%d bytes: expected %v, got %v", n, errTestWrite, err)
+		}
+	}
+}
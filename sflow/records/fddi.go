@@ -0,0 +1,34 @@
+package records
+
+import (
+	"bytes"
+)
+
+// FDDIHeader as found in RawPacketFlow.Header
+type FDDIHeader struct {
+	FrameControl uint8        `json:"frameControl"`
+	DstMac       HardwareAddr `json:"dstMac"`
+	SrcMac       HardwareAddr `json:"srcMac"`
+}
+
+// IsLLC returns true for asynchronous LLC frames, which carry an 802.2 LLC header
+func (fddi FDDIHeader) IsLLC() bool {
+	return fddi.FrameControl&0xf8 == 0x50
+}
+
+// decodeFDDI decodes a FDDI frame and its LLC payload
+func decodeFDDI(h *bytes.Reader, p *DecodedPacket) error {
+	fddi := FDDIHeader{}
+
+	_, err := decodeInto(h, &fddi)
+	p.link().FDDI = &fddi
+	if err != nil {
+		return err
+	}
+
+	if !fddi.IsLLC() {
+		return nil
+	}
+
+	return decodeLLC(h, p)
+}
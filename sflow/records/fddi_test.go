@@ -0,0 +1,27 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeFDDI(t *testing.T) {
+	header := []byte{
+		// Asynchronous LLC frame
+		0x50,
+		0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb,
+		// LLC, SNAP, IPv4
+		0xaa, 0xaa, 0x03, 0x00, 0x00, 0x00, 0x08, 0x00,
+	}
+	header = append(header, testIPv4UDPPacket...)
+
+	p := decodeTestPacket(t, HeaderProtocolFDDI, header)
+
+	if fddi := *p.link().FDDI; !fddi.IsLLC() {
+		t.Errorf("expected a LLC frame, got %+v", fddi)
+	}
+
+	if udp := p.transport().UDP; !reflect.DeepEqual(udp, &testIPv4UDPHeader) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", testIPv4UDPHeader, udp)
+	}
+}
@@ -0,0 +1,85 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Network Layer Protocol IDs used by the multiprotocol encapsulations of Frame Relay (RFC 2427) and ATM (RFC 2684)
+const (
+	NLPIDSNAP = 0x80
+	NLPIDIPv4 = 0xcc
+	NLPIDIPv6 = 0x8e
+)
+
+// FrameRelayControlUI is the control field of unnumbered information frames
+const FrameRelayControlUI = 0x03
+
+// FrameRelayHeader is the Q.922 address of a Frame Relay frame as found in RawPacketFlow.Header
+type FrameRelayHeader struct {
+	Address uint16 `json:"address"`
+	DLCI    uint16 `ignoreOnMarshal:"true" json:"dlci"`
+	FECN    bool   `ignoreOnMarshal:"true" json:"fecn"`
+	BECN    bool   `ignoreOnMarshal:"true" json:"becn"`
+	DE      bool   `ignoreOnMarshal:"true" json:"de"`
+	NLPID   uint8  `ignoreOnMarshal:"true" json:"nlpid,omitempty"`
+}
+
+// decodeFrameRelay decodes a Frame Relay frame and its payload. Besides the RFC 2427 encapsulation
+// frames carrying an EtherType directly after the address (as sent by Cisco equipment) are recognised.
+func decodeFrameRelay(h *bytes.Reader, p *DecodedPacket) error {
+	fr := FrameRelayHeader{}
+
+	if _, err := decodeInto(h, &fr); err != nil {
+		return err
+	}
+
+	// 6 bits DLCI, C/R, EA, 4 bits DLCI, FECN, BECN, DE, EA
+	fr.DLCI = (fr.Address>>10)<<4 | (fr.Address>>4)&0x0f
+	fr.FECN = fr.Address&0x08 != 0
+	fr.BECN = fr.Address&0x04 != 0
+	fr.DE = fr.Address&0x02 != 0
+	p.link().FrameRelay = &fr
+
+	control, err := h.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	if control != FrameRelayControlUI {
+		var etherType uint16
+		if err = h.UnreadByte(); err != nil {
+			return err
+		}
+		if err = binary.Read(h, binary.BigEndian, &etherType); err != nil {
+			return err
+		}
+		return decodeEtherType(etherType, h, p)
+	}
+
+	// An optional padding octet precedes the NLPID
+	if fr.NLPID, err = h.ReadByte(); err != nil {
+		return err
+	}
+	if fr.NLPID == 0 {
+		if fr.NLPID, err = h.ReadByte(); err != nil {
+			return err
+		}
+	}
+
+	return decodeNLPID(fr.NLPID, h, p)
+}
+
+// decodeNLPID decodes the payload identified by a Network Layer Protocol ID
+func decodeNLPID(nlpid uint8, h *bytes.Reader, p *DecodedPacket) error {
+	switch nlpid {
+	case NLPIDIPv4:
+		return decodeIPHeader(4, h, p)
+	case NLPIDIPv6:
+		return decodeIPHeader(6, h, p)
+	case NLPIDSNAP:
+		return decodeSNAP(h, p)
+	}
+
+	return nil
+}
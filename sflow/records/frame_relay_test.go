@@ -0,0 +1,38 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeFrameRelay(t *testing.T) {
+	// DLCI 100 with BECN, UI, NLPID IPv4
+	header := append([]byte{0x18, 0x45, 0x03, 0xcc}, testIPv4UDPPacket...)
+
+	p := decodeTestPacket(t, HeaderProtocolFrameRelay, header)
+
+	expected := FrameRelayHeader{Address: 0x1845, DLCI: 100, BECN: true, NLPID: NLPIDIPv4}
+	if fr := p.link().FrameRelay; !reflect.DeepEqual(fr, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, fr)
+	}
+
+	if udp := p.transport().UDP; !reflect.DeepEqual(udp, &testIPv4UDPHeader) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", testIPv4UDPHeader, udp)
+	}
+}
+
+func TestDecodeFrameRelayEtherType(t *testing.T) {
+	// DLCI 100, EtherType IPv4
+	header := append([]byte{0x18, 0x41, 0x08, 0x00}, testIPv4UDPPacket...)
+
+	p := decodeTestPacket(t, HeaderProtocolFrameRelay, header)
+
+	expected := FrameRelayHeader{Address: 0x1841, DLCI: 100}
+	if fr := p.link().FrameRelay; !reflect.DeepEqual(fr, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, fr)
+	}
+
+	if udp := p.transport().UDP; !reflect.DeepEqual(udp, &testIPv4UDPHeader) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", testIPv4UDPHeader, udp)
+	}
+}
@@ -0,0 +1,67 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// GRE Flags
+const (
+	GREFlagChecksum = 0x8000
+	GREFlagKey      = 0x2000
+	GREFlagSequence = 0x1000
+)
+
+// GREHeader as found in RawPacketFlow.Header
+// ProtocolType is the EtherType of the encapsulated packet.
+type GREHeader struct {
+	FlagsVersion uint16 `json:"flagsVersion"`
+	ProtocolType uint16 `json:"protocolType"`
+	Version      uint8  `ignoreOnMarshal:"true" json:"version"`
+	Key          uint32 `ignoreOnMarshal:"true" json:"key,omitempty"`
+	Sequence     uint32 `ignoreOnMarshal:"true" json:"sequence,omitempty"`
+}
+
+// decodeGREHeader decodes a GRE header (RFC 2784, RFC 2890) including its optional fields
+func decodeGREHeader(h *bytes.Reader) (GREHeader, error) {
+	gre := GREHeader{}
+
+	if _, err := decodeInto(h, &gre); err != nil {
+		return gre, err
+	}
+	gre.Version = uint8(gre.FlagsVersion & 0x0007)
+
+	// Checksum and reserved field
+	if gre.FlagsVersion&GREFlagChecksum != 0 {
+		var checksum uint32
+		if err := binary.Read(h, binary.BigEndian, &checksum); err != nil {
+			return gre, err
+		}
+	}
+
+	if gre.FlagsVersion&GREFlagKey != 0 {
+		if err := binary.Read(h, binary.BigEndian, &gre.Key); err != nil {
+			return gre, err
+		}
+	}
+
+	if gre.FlagsVersion&GREFlagSequence != 0 {
+		if err := binary.Read(h, binary.BigEndian, &gre.Sequence); err != nil {
+			return gre, err
+		}
+	}
+
+	return gre, nil
+}
+
+// dissectGRE decodes a GRE header and the encapsulated packet from h into p
+func dissectGRE(h *bytes.Reader, p *DecodedPacket) error {
+	gre, err := decodeGREHeader(h)
+	p.transport().GRE = &gre
+	if err != nil {
+		return err
+	}
+
+	p.Inner = p.encapsulated()
+	return decodeEtherType(gre.ProtocolType, h, p.Inner)
+}
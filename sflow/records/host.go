@@ -6,12 +6,12 @@ package records
 
 type HostDescriptionCounter struct {
 	HostnameLen uint32
-	Hostname []byte `lengthLookUp:"HostnameLen"`
+	Hostname []byte `lengthLookUp:"HostnameLen" maxLength:"64"`
 	UUID [16]byte
 	MachineType uint32
 	OSName uint32
 	OSReleaseLen uint32
-	OSRelease []byte `lengthLookUp:"OSReleaseLen"`
+	OSRelease []byte `lengthLookUp:"OSReleaseLen" maxLength:"32"`
 }
 
 // RecordName returns the Name of this flow record
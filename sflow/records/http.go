@@ -1,6 +1,8 @@
 package records
 
 import (
+	"encoding/binary"
+	"fmt"
 	"io"
 )
 
@@ -17,28 +19,52 @@ const (
 	HTTPConnect = 8
 )
 
+var httpMethodNames = map[uint32]string{
+	HTTPOther:   "OTHER",
+	HTTPOptions: "OPTIONS",
+	HTTPGet:     "GET",
+	HTTPHead:    "HEAD",
+	HTTPPost:    "POST",
+	HTTPPut:     "PUT",
+	HTTPDelete:  "DELETE",
+	HTTPTrace:   "TRACE",
+	HTTPConnect: "CONNECT",
+}
+
+// HTTPMethodName returns the name of a HTTP request method code
+func HTTPMethodName(method uint32) string {
+	return lookupName(httpMethodNames, method)
+}
+
+// HTTPProtocolName returns the name of a HTTP protocol version encoded as major_number * 1000 + minor_number
+func HTTPProtocolName(protocol uint32) string {
+	return fmt.Sprintf("HTTP/%d.%d", protocol/1000, protocol%1000)
+}
+
 // HTTPRequestFlow - TypeHTTPRequestFlowRecord
 type HTTPRequestFlow struct {
 	Method       uint32
 	Protocol     uint32 /* HTTP protocol version: Encoded as major_number * 1000 + minor_number. e.g. HTTP1.1 is encoded as 1001 */
 	URILen       uint32
-	URI          []byte `lengthLookUp:"URILen"` /* URI exactly as it came from the client */
+	URI          XDRString `lengthLookUp:"URILen" maxLength:"255"` /* URI exactly as it came from the client */
 	HostLen      uint32
-	Host         []byte `lengthLookUp:"HostLen"` /* Host value from request header */
+	Host         XDRString `lengthLookUp:"HostLen" maxLength:"64"` /* Host value from request header */
 	RefererLen   uint32
-	Referer      []byte `lengthLookUp:"RefererLen"` /* Referer value from request header */
+	Referer      XDRString `lengthLookUp:"RefererLen" maxLength:"255"` /* Referer value from request header */
 	UserAgentLen uint32
-	UserAgent    []byte `lengthLookUp:"UserAgentLen"` /* User-Agent value from request header */
+	UserAgent    XDRString `lengthLookUp:"UserAgentLen" maxLength:"128"` /* User-Agent value from request header */
 	XFFLen       uint32
-	XFF          []byte `lengthLookUp:"XFFLen"` /* X-Forwarded-For value from request header */
+	XFF          XDRString `lengthLookUp:"XFFLen" maxLength:"64"` /* X-Forwarded-For value from request header */
 	AuthUserLen  uint32
-	AuthUser     []byte `lengthLookUp:"AuthUserLen"` /* RFC 1413 identity of user*/
+	AuthUser     XDRString `lengthLookUp:"AuthUserLen" maxLength:"32"` /* RFC 1413 identity of user*/
 	MimeTypeLen  uint32
-	MimeType     []byte `lengthLookUp:"MimeTypeLen"` /* Mime-Type of response */
-	ReqBytes     uint64 /* Content-Length of request */
-	RespBytes    uint64 /* Content-Length of response */
-	Duration     uint32 /* duration of the operation (in microseconds) */
-	Status       uint32 /* HTTP status code */
+	MimeType     XDRString `lengthLookUp:"MimeTypeLen" maxLength:"64"` /* Mime-Type of response */
+	ReqBytes     uint64    /* Content-Length of request */
+	RespBytes    uint64    /* Content-Length of response */
+	Duration     uint32    /* duration of the operation (in microseconds) */
+	Status       int32     /* HTTP status code */
+	MethodName   string    `ignoreOnMarshal:"true"`
+	ProtocolName string    `ignoreOnMarshal:"true"`
 }
 
 // RecordName returns the Name of this flow record
@@ -51,10 +77,54 @@ func (f HTTPRequestFlow) RecordType() int {
 	return TypeHTTPRequestFlowRecord
 }
 
+func (f HTTPRequestFlow) calculateBinarySize() int {
+	var size int
+
+	size += binary.Size(f.Method)
+	size += binary.Size(f.Protocol)
+	size += binary.Size(f.URILen)
+	size += paddedLength(len(f.URI))
+	size += binary.Size(f.HostLen)
+	size += paddedLength(len(f.Host))
+	size += binary.Size(f.RefererLen)
+	size += paddedLength(len(f.Referer))
+	size += binary.Size(f.UserAgentLen)
+	size += paddedLength(len(f.UserAgent))
+	size += binary.Size(f.XFFLen)
+	size += paddedLength(len(f.XFF))
+	size += binary.Size(f.AuthUserLen)
+	size += paddedLength(len(f.AuthUser))
+	size += binary.Size(f.MimeTypeLen)
+	size += paddedLength(len(f.MimeType))
+	size += binary.Size(f.ReqBytes)
+	size += binary.Size(f.RespBytes)
+	size += binary.Size(f.Duration)
+	size += binary.Size(f.Status)
+
+	return size
+}
+
+func (f *HTTPRequestFlow) PostDecode() error {
+	f.MethodName = HTTPMethodName(f.Method)
+	f.ProtocolName = HTTPProtocolName(f.Protocol)
+
+	return nil
+}
+
 func (f HTTPRequestFlow) Encode(w io.Writer) error {
 	var err error
 
-	return err
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
 }
 
 // HTTPCounters - TypeHTTPCounterRecord
@@ -86,14 +156,67 @@ func (f HTTPCounter) RecordType() int {
 	return TypeHTTPCounterRecord
 }
 
+func (f HTTPCounter) calculateBinarySize() int {
+	return binary.Size(f)
+}
+
 func (f HTTPCounter) Encode(w io.Writer) error {
 	var err error
 
-	return err
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, f)
 }
 
 // ExtendedProxyRequest - TypeHTTPExtendedProxyFlowRecord
 type ExtendedProxyRequestFlow struct {
-	//string<255> uri;           /* URI in request to downstream server */
-	//string<64>  host;          /* Host in request to downstream server */
+	URILen  uint32
+	URI     XDRString `lengthLookUp:"URILen" maxLength:"255"` /* URI in request to downstream server */
+	HostLen uint32
+	Host    XDRString `lengthLookUp:"HostLen" maxLength:"64"` /* Host in request to downstream server */
+}
+
+// RecordName returns the Name of this flow record
+func (f ExtendedProxyRequestFlow) RecordName() string {
+	return "ExtendedProxyRequestFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f ExtendedProxyRequestFlow) RecordType() int {
+	return TypeHTTPExtendedProxyFlowRecord
+}
+
+func (f ExtendedProxyRequestFlow) calculateBinarySize() int {
+	var size int
+
+	size += binary.Size(f.URILen)
+	size += paddedLength(len(f.URI))
+	size += binary.Size(f.HostLen)
+	size += paddedLength(len(f.Host))
+
+	return size
+}
+
+func (f ExtendedProxyRequestFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
 }
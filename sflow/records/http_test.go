@@ -0,0 +1,171 @@
+package records
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeHTTPRequestFlowRecord(t *testing.T) {
+	rec := HTTPRequestFlow{
+		Method:       HTTPGet,
+		Protocol:     1001,
+		URILen:       18,
+		URI:          XDRString("/index.html?page=2"),
+		HostLen:      11,
+		Host:         XDRString("example.com"),
+		RefererLen:   0,
+		UserAgentLen: 11,
+		UserAgent:    XDRString("curl/7.68.0"),
+		XFFLen:       8,
+		XFF:          XDRString("10.0.0.1"),
+		AuthUserLen:  4,
+		AuthUser:     XDRString("jdoe"),
+		MimeTypeLen:  9,
+		MimeType:     XDRString("text/html"),
+		ReqBytes:     0,
+		RespBytes:    5120,
+		Duration:     2300,
+		Status:       200,
+		MethodName:   "GET",
+		ProtocolName: "HTTP/1.1",
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+rec.calculateBinarySize() {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+rec.calculateBinarySize(), b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeFlow(b, TypeHTTPRequestFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+
+	if b.Len() != 0 {
+		t.Errorf("expected the record to be consumed completely, %d bytes left", b.Len())
+	}
+}
+
+func TestEncodeHTTPRequestFlowWithoutLengths(t *testing.T) {
+	rec := HTTPRequestFlow{
+		Method: HTTPGet,
+		URI:    XDRString("/index.html"),
+		Host:   XDRString("example.com"),
+		Status: 200,
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeFlow(b, TypeHTTPRequestFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The lengths are written from the strings
+	expected := rec
+	expected.URILen, expected.HostLen = 11, 11
+	expected.MethodName, expected.ProtocolName = "GET", HTTPProtocolName(0)
+
+	if !reflect.DeepEqual(expected, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, decoded)
+	}
+}
+
+func TestDecodeHTTPRequestFlowNegativeStatus(t *testing.T) {
+	// The status is a signed int in the sFlow structures
+	b := make([]byte, 2*4+7*4+2*8+2*4)
+	copy(b[len(b)-4:], []byte{0xff, 0xff, 0xff, 0xff})
+
+	decoded, err := DecodeFlow(bytes.NewReader(b), TypeHTTPRequestFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status := decoded.(HTTPRequestFlow).Status; status != -1 {
+		t.Errorf("expected the status -1, got %d", status)
+	}
+}
+
+func TestEncodeDecodeHTTPCounterRecord(t *testing.T) {
+	rec := HTTPCounter{
+		MethodGetCount:  1000,
+		MethodPostCount: 250,
+		Status2XXCount:  1200,
+		Status4XXCount:  45,
+		Status5XXCount:  5,
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+15*4 {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+15*4, b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeCounter(b, TypeHTTPCounterRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestEncodeDecodeExtendedProxyRequestFlowRecord(t *testing.T) {
+	rec := ExtendedProxyRequestFlow{
+		URILen:  10,
+		URI:     XDRString("/v2/orders"),
+		HostLen: 18,
+		Host:    XDRString("orders.internal:80"),
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+4+12+4+20 {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+4+12+4+20, b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeFlow(b, TypeHTTPExtendedProxyFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestHTTPProtocolName(t *testing.T) {
+	if name := HTTPProtocolName(2000); name != "HTTP/2.0" {
+		t.Errorf("expected HTTP/2.0, got %s", name)
+	}
+}
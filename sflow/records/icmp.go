@@ -0,0 +1,308 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+// ICMP Types (see https://www.iana.org/assignments/icmp-parameters)
+const (
+	ICMPTypeEchoReply              = 0
+	ICMPTypeDestinationUnreachable = 3
+	ICMPTypeSourceQuench           = 4
+	ICMPTypeRedirect               = 5
+	ICMPTypeEchoRequest            = 8
+	ICMPTypeRouterAdvertisement    = 9
+	ICMPTypeRouterSolicitation     = 10
+	ICMPTypeTimeExceeded           = 11
+	ICMPTypeParameterProblem       = 12
+	ICMPTypeTimestamp              = 13
+	ICMPTypeTimestampReply         = 14
+	ICMPTypeInformationRequest     = 15
+	ICMPTypeInformationReply       = 16
+	ICMPTypeAddressMaskRequest     = 17
+	ICMPTypeAddressMaskReply       = 18
+)
+
+// ICMP Destination Unreachable Codes
+const (
+	ICMPCodeNetUnreachable      = 0
+	ICMPCodeHostUnreachable     = 1
+	ICMPCodeProtocolUnreachable = 2
+	ICMPCodePortUnreachable     = 3
+	ICMPCodeFragmentationNeeded = 4
+)
+
+var icmpTypeNames = map[uint32]string{
+	ICMPTypeEchoReply:              "echo-reply",
+	ICMPTypeDestinationUnreachable: "destination-unreachable",
+	ICMPTypeSourceQuench:           "source-quench",
+	ICMPTypeRedirect:               "redirect",
+	ICMPTypeEchoRequest:            "echo-request",
+	ICMPTypeRouterAdvertisement:    "router-advertisement",
+	ICMPTypeRouterSolicitation:     "router-solicitation",
+	ICMPTypeTimeExceeded:           "time-exceeded",
+	ICMPTypeParameterProblem:       "parameter-problem",
+	ICMPTypeTimestamp:              "timestamp",
+	ICMPTypeTimestampReply:         "timestamp-reply",
+	ICMPTypeInformationRequest:     "information-request",
+	ICMPTypeInformationReply:       "information-reply",
+	ICMPTypeAddressMaskRequest:     "address-mask-request",
+	ICMPTypeAddressMaskReply:       "address-mask-reply",
+}
+
+var icmpCodeNames = map[uint8]map[uint32]string{
+	ICMPTypeDestinationUnreachable: {
+		ICMPCodeNetUnreachable:      "net-unreachable",
+		ICMPCodeHostUnreachable:     "host-unreachable",
+		ICMPCodeProtocolUnreachable: "protocol-unreachable",
+		ICMPCodePortUnreachable:     "port-unreachable",
+		ICMPCodeFragmentationNeeded: "fragmentation-needed",
+		5:                           "source-route-failed",
+		6:                           "destination-network-unknown",
+		7:                           "destination-host-unknown",
+		8:                           "source-host-isolated",
+		9:                           "network-prohibited",
+		10:                          "host-prohibited",
+		11:                          "network-unreachable-for-tos",
+		12:                          "host-unreachable-for-tos",
+		13:                          "communication-prohibited",
+		14:                          "host-precedence-violation",
+		15:                          "precedence-cutoff",
+	},
+	ICMPTypeRedirect: {
+		0: "redirect-network",
+		1: "redirect-host",
+		2: "redirect-tos-network",
+		3: "redirect-tos-host",
+	},
+	ICMPTypeTimeExceeded: {
+		0: "ttl-exceeded",
+		1: "fragment-reassembly-exceeded",
+	},
+	ICMPTypeParameterProblem: {
+		0: "pointer-indicates-error",
+		1: "missing-required-option",
+		2: "bad-length",
+	},
+}
+
+// ICMPv6 Types (see https://www.iana.org/assignments/icmpv6-parameters)
+const (
+	ICMPv6TypeDestinationUnreachable = 1
+	ICMPv6TypePacketTooBig           = 2
+	ICMPv6TypeTimeExceeded           = 3
+	ICMPv6TypeParameterProblem       = 4
+	ICMPv6TypeEchoRequest            = 128
+	ICMPv6TypeEchoReply              = 129
+	ICMPv6TypeMLDQuery               = 130
+	ICMPv6TypeMLDReport              = 131
+	ICMPv6TypeMLDDone                = 132
+	ICMPv6TypeRouterSolicitation     = 133
+	ICMPv6TypeRouterAdvertisement    = 134
+	ICMPv6TypeNeighborSolicitation   = 135
+	ICMPv6TypeNeighborAdvertisement  = 136
+	ICMPv6TypeRedirect               = 137
+	ICMPv6TypeMLDv2Report            = 143
+)
+
+var icmpv6TypeNames = map[uint32]string{
+	ICMPv6TypeDestinationUnreachable: "destination-unreachable",
+	ICMPv6TypePacketTooBig:           "packet-too-big",
+	ICMPv6TypeTimeExceeded:           "time-exceeded",
+	ICMPv6TypeParameterProblem:       "parameter-problem",
+	ICMPv6TypeEchoRequest:            "echo-request",
+	ICMPv6TypeEchoReply:              "echo-reply",
+	ICMPv6TypeMLDQuery:               "mld-query",
+	ICMPv6TypeMLDReport:              "mld-report",
+	ICMPv6TypeMLDDone:                "mld-done",
+	ICMPv6TypeRouterSolicitation:     "router-solicitation",
+	ICMPv6TypeRouterAdvertisement:    "router-advertisement",
+	ICMPv6TypeNeighborSolicitation:   "neighbor-solicitation",
+	ICMPv6TypeNeighborAdvertisement:  "neighbor-advertisement",
+	ICMPv6TypeRedirect:               "redirect",
+	ICMPv6TypeMLDv2Report:            "mldv2-report",
+}
+
+var icmpv6CodeNames = map[uint8]map[uint32]string{
+	ICMPv6TypeDestinationUnreachable: {
+		0: "no-route",
+		1: "communication-prohibited",
+		2: "beyond-scope",
+		3: "address-unreachable",
+		4: "port-unreachable",
+		5: "source-policy-failed",
+		6: "reject-route",
+	},
+	ICMPv6TypeTimeExceeded: {
+		0: "hop-limit-exceeded",
+		1: "fragment-reassembly-exceeded",
+	},
+	ICMPv6TypeParameterProblem: {
+		0: "erroneous-header-field",
+		1: "unrecognized-next-header",
+		2: "unrecognized-option",
+	},
+}
+
+// NDP Option Types
+const (
+	NDPOptionSourceLinkLayerAddress = 1
+	NDPOptionTargetLinkLayerAddress = 2
+)
+
+// ICMPHeader as found in RawPacketFlow.Header, used for ICMP and ICMPv6
+// The fields following the checksum are only set for the message types they apply to.
+type ICMPHeader struct {
+	Type     uint8  `json:"type"`
+	Code     uint8  `json:"code"`
+	Checksum uint16 `json:"checksum"`
+	TypeName string `ignoreOnMarshal:"true" json:"typeName"`
+	CodeName string `ignoreOnMarshal:"true" json:"codeName,omitempty"`
+
+	Identifier uint16 `ignoreOnMarshal:"true" json:"identifier,omitempty"` // echo and timestamp messages
+	Sequence   uint16 `ignoreOnMarshal:"true" json:"sequence,omitempty"`   // echo and timestamp messages
+	MTU        uint32 `ignoreOnMarshal:"true" json:"mtu,omitempty"`        // fragmentation needed / packet too big
+	Pointer    uint8  `ignoreOnMarshal:"true" json:"pointer,omitempty"`    // parameter problem
+	Gateway    net.IP `ignoreOnMarshal:"true" json:"gateway,omitempty"`    // ICMP redirect
+
+	// Neighbor Discovery (ICMPv6 only)
+	TargetAddress      net.IP       `ignoreOnMarshal:"true" json:"targetAddress,omitempty"`
+	DestinationAddress net.IP       `ignoreOnMarshal:"true" json:"destinationAddress,omitempty"` // redirect
+	LinkLayerAddress   HardwareAddr `ignoreOnMarshal:"true" json:"linkLayerAddress,omitempty"`
+	Router             bool         `ignoreOnMarshal:"true" json:"router,omitempty"`
+	Solicited          bool         `ignoreOnMarshal:"true" json:"solicited,omitempty"`
+	Override           bool         `ignoreOnMarshal:"true" json:"override,omitempty"`
+
+	// Headers of the packet which triggered an error message
+	Original *DecodedPacket `ignoreOnMarshal:"true" json:"original,omitempty"`
+}
+
+// isError returns true for error messages, which quote the headers of the original packet
+func (icmp ICMPHeader) isError(ipVersion int) bool {
+	if ipVersion == 6 {
+		return icmp.Type < 128
+	}
+
+	switch icmp.Type {
+	case ICMPTypeDestinationUnreachable, ICMPTypeSourceQuench, ICMPTypeRedirect,
+		ICMPTypeTimeExceeded, ICMPTypeParameterProblem:
+		return true
+	}
+	return false
+}
+
+// decodeICMPHeader decodes an ICMP (ipVersion 4) or ICMPv6 (ipVersion 6) header including
+// the type specific rest of the header. For error messages the headers of the original
+// packet are decoded as well, unless the message itself is embedded in an error message.
+func decodeICMPHeader(h *bytes.Reader, ipVersion int, embedded bool) (ICMPHeader, error) {
+	icmp := ICMPHeader{}
+
+	if _, err := decodeInto(h, &icmp); err != nil {
+		return icmp, err
+	}
+
+	if ipVersion == 6 {
+		icmp.TypeName = lookupName(icmpv6TypeNames, uint32(icmp.Type))
+		if codes, found := icmpv6CodeNames[icmp.Type]; found {
+			icmp.CodeName = lookupName(codes, uint32(icmp.Code))
+		}
+	} else {
+		icmp.TypeName = lookupName(icmpTypeNames, uint32(icmp.Type))
+		if codes, found := icmpCodeNames[icmp.Type]; found {
+			icmp.CodeName = lookupName(codes, uint32(icmp.Code))
+		}
+	}
+
+	// The 4 bytes following the checksum depend on the type of the message
+	rest := [4]byte{}
+	if _, err := io.ReadFull(h, rest[:]); err != nil {
+		return icmp, err
+	}
+
+	switch {
+	case ipVersion == 4 && (icmp.Type == ICMPTypeEchoRequest || icmp.Type == ICMPTypeEchoReply ||
+		(icmp.Type >= ICMPTypeTimestamp && icmp.Type <= ICMPTypeAddressMaskReply)),
+		ipVersion == 6 && (icmp.Type == ICMPv6TypeEchoRequest || icmp.Type == ICMPv6TypeEchoReply):
+		icmp.Identifier = binary.BigEndian.Uint16(rest[0:2])
+		icmp.Sequence = binary.BigEndian.Uint16(rest[2:4])
+	case ipVersion == 4 && icmp.Type == ICMPTypeDestinationUnreachable && icmp.Code == ICMPCodeFragmentationNeeded:
+		icmp.MTU = uint32(binary.BigEndian.Uint16(rest[2:4]))
+	case ipVersion == 4 && icmp.Type == ICMPTypeRedirect:
+		icmp.Gateway = make(net.IP, net.IPv4len)
+		copy(icmp.Gateway, rest[:])
+	case ipVersion == 4 && icmp.Type == ICMPTypeParameterProblem:
+		icmp.Pointer = rest[0]
+	case ipVersion == 6 && icmp.Type == ICMPv6TypePacketTooBig:
+		icmp.MTU = binary.BigEndian.Uint32(rest[:])
+	case ipVersion == 6 && icmp.Type >= ICMPv6TypeRouterSolicitation && icmp.Type <= ICMPv6TypeRedirect:
+		return icmp, decodeNDP(h, &icmp, rest)
+	}
+
+	if icmp.isError(ipVersion) && !embedded {
+		icmp.Original = &DecodedPacket{embedded: true}
+		return icmp, decodeIPHeader(ipVersion, h, icmp.Original)
+	}
+
+	return icmp, nil
+}
+
+// decodeNDP decodes the IPv6 Neighbor Discovery message following an ICMPv6 header
+func decodeNDP(h *bytes.Reader, icmp *ICMPHeader, rest [4]byte) error {
+	switch icmp.Type {
+	case ICMPv6TypeRouterAdvertisement:
+		// Reachable Time and Retrans Timer
+		if _, err := h.Seek(8, io.SeekCurrent); err != nil {
+			return err
+		}
+	case ICMPv6TypeNeighborAdvertisement:
+		icmp.Router = rest[0]&0x80 != 0
+		icmp.Solicited = rest[0]&0x40 != 0
+		icmp.Override = rest[0]&0x20 != 0
+		fallthrough
+	case ICMPv6TypeNeighborSolicitation, ICMPv6TypeRedirect:
+		icmp.TargetAddress = make(net.IP, net.IPv6len)
+		if _, err := io.ReadFull(h, icmp.TargetAddress); err != nil {
+			return err
+		}
+
+		if icmp.Type == ICMPv6TypeRedirect {
+			icmp.DestinationAddress = make(net.IP, net.IPv6len)
+			if _, err := io.ReadFull(h, icmp.DestinationAddress); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Options are encoded as type, length in units of 8 octets and value
+	for h.Len() >= 8 {
+		off := h.Size() - int64(h.Len())
+
+		option := [2]uint8{}
+		if err := binary.Read(h, binary.BigEndian, &option); err != nil {
+			return err
+		}
+
+		length := int(option[1]) * 8
+		if length == 0 {
+			return fmt.Errorf("%w: zero length NDP option at offset %d", ErrDecodingRecord, off)
+		}
+
+		value := make([]byte, length-2)
+		if _, err := io.ReadFull(h, value); err != nil {
+			return err
+		}
+
+		switch option[0] {
+		case NDPOptionSourceLinkLayerAddress, NDPOptionTargetLinkLayerAddress:
+			if len(value) >= 6 {
+				icmp.LinkLayerAddress = HardwareAddr(value[:6])
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package records
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeICMPPortUnreachable(t *testing.T) {
+	header := []byte{
+		// Ethernet
+		0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0x08, 0x00,
+		// IPv4 192.0.2.1 -> 198.51.100.7, ICMP
+		0x45, 0x00, 0x00, 0x38, 0x00, 0x01, 0x00, 0x00, 0x40, 0x01, 0x00, 0x00,
+		192, 0, 2, 1, 198, 51, 100, 7,
+		// ICMP Destination Unreachable, Port Unreachable
+		0x03, 0x03, 0xab, 0xcd, 0x00, 0x00, 0x00, 0x00,
+		// Original IPv4 198.51.100.7 -> 192.0.2.1, UDP
+		0x45, 0x00, 0x00, 0x20, 0x00, 0x02, 0x00, 0x00, 0x40, 0x11, 0x00, 0x00,
+		198, 51, 100, 7, 192, 0, 2, 1,
+		// Original UDP 40000 -> 53
+		0x9c, 0x40, 0x00, 0x35, 0x00, 0x0c, 0x00, 0x00,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolEthernetISO8023, header)
+
+	icmp := p.transport().ICMP
+	if icmp == nil {
+		t.Fatalf("expected an ICMP header, got %+v", p)
+	}
+
+	if icmp.TypeName != "destination-unreachable" || icmp.CodeName != "port-unreachable" {
+		t.Errorf("unexpected type %q and code %q", icmp.TypeName, icmp.CodeName)
+	}
+
+	if icmp.Original == nil || icmp.Original.network().IPv4 == nil {
+		t.Fatalf("expected an embedded IPv4 header, got %+v", icmp.Original)
+	}
+	ip := icmp.Original.Network.IPv4
+	if !ip.SrcAddr.Equal(net.IPv4(198, 51, 100, 7)) || !ip.DstAddr.Equal(net.IPv4(192, 0, 2, 1)) {
+		t.Errorf("unexpected embedded addresses %s -> %s", ip.SrcAddr, ip.DstAddr)
+	}
+
+	expected := UDPHeader{SrcPort: 40000, DstPort: 53, Length: 12}
+	if udp := icmp.Original.transport().UDP; !reflect.DeepEqual(udp, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, udp)
+	}
+}
+
+func TestDecodeICMPFragmentationNeeded(t *testing.T) {
+	header := []byte{
+		// IPv4 192.0.2.1 -> 198.51.100.7, ICMP
+		0x45, 0x00, 0x00, 0x38, 0x00, 0x01, 0x00, 0x00, 0x40, 0x01, 0x00, 0x00,
+		192, 0, 2, 1, 198, 51, 100, 7,
+		// ICMP Destination Unreachable, Fragmentation Needed, MTU 1400
+		0x03, 0x04, 0x00, 0x00, 0x00, 0x00, 0x05, 0x78,
+		// Original IPv4 198.51.100.7 -> 192.0.2.1, TCP
+		0x45, 0x00, 0x05, 0xdc, 0x00, 0x02, 0x40, 0x00, 0x40, 0x06, 0x00, 0x00,
+		198, 51, 100, 7, 192, 0, 2, 1,
+		// First 8 bytes of the original TCP header
+		0x01, 0xbb, 0xc3, 0x50, 0x00, 0x00, 0x00, 0x01,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, header)
+
+	icmp := *p.transport().ICMP
+	if icmp.MTU != 1400 {
+		t.Errorf("expected MTU 1400, got %d", icmp.MTU)
+	}
+
+	if icmp.Original == nil || icmp.Original.transport().TCP == nil {
+		t.Fatalf("expected a truncated embedded TCP header, got %+v", icmp.Original)
+	}
+	tcp := icmp.Original.Transport.TCP
+	if tcp.SrcPort != 443 || tcp.DstPort != 50000 || tcp.Seq != 1 {
+		t.Errorf("unexpected embedded TCP header %+v", tcp)
+	}
+}
+
+func TestDecodeICMPv6Echo(t *testing.T) {
+	header := []byte{
+		// IPv6 2001:db8::1 -> 2001:db8::2, ICMPv6
+		0x60, 0x00, 0x00, 0x00, 0x00, 0x08, 0x3a, 0x40,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2,
+		// ICMPv6 Echo Request, id 0x1234, seq 7
+		0x80, 0x00, 0xab, 0xcd, 0x12, 0x34, 0x00, 0x07,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv6, header)
+
+	expected := ICMPHeader{
+		Type:       ICMPv6TypeEchoRequest,
+		Checksum:   0xabcd,
+		TypeName:   "echo-request",
+		Identifier: 0x1234,
+		Sequence:   7,
+	}
+
+	if icmp := p.transport().ICMPv6; !reflect.DeepEqual(icmp, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, icmp)
+	}
+}
+
+func TestDecodeICMPv6NeighborSolicitation(t *testing.T) {
+	header := []byte{
+		// Ethernet
+		0x33, 0x33, 0xff, 0x00, 0x00, 0x02, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x86, 0xdd,
+		// IPv6 2001:db8::1 -> ff02::1:ff00:2, ICMPv6
+		0x60, 0x00, 0x00, 0x00, 0x00, 0x20, 0x3a, 0xff,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
+		0xff, 0x02, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0xff, 0, 0, 2,
+		// ICMPv6 Neighbor Solicitation
+		0x87, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2,
+		// Source Link-Layer Address option
+		0x01, 0x01, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolEthernetISO8023, header)
+
+	icmp := *p.transport().ICMPv6
+	if icmp.TypeName != "neighbor-solicitation" {
+		t.Errorf("unexpected type %q", icmp.TypeName)
+	}
+	if !icmp.TargetAddress.Equal(net.ParseIP("2001:db8::2")) {
+		t.Errorf("unexpected target address %s", icmp.TargetAddress)
+	}
+	if net.HardwareAddr(icmp.LinkLayerAddress).String() != "00:11:22:33:44:55" {
+		t.Errorf("unexpected link-layer address %s", net.HardwareAddr(icmp.LinkLayerAddress))
+	}
+}
+
+func TestDecodeICMPv6ZeroLengthNDPOption(t *testing.T) {
+	header := []byte{
+		// IPv6 2001:db8::1 -> ff02::1:ff00:2, ICMPv6
+		0x60, 0x00, 0x00, 0x00, 0x00, 0x20, 0x3a, 0xff,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
+		0xff, 0x02, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0xff, 0, 0, 2,
+		// ICMPv6 Neighbor Solicitation
+		0x87, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2,
+		// Source Link-Layer Address option with a length of 0
+		0x01, 0x00, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv6, header)
+
+	if len(p.Errors) != 1 || !strings.Contains(p.Errors[0], "zero length NDP option at offset 64") {
+		t.Errorf("expected the error of the option at offset 64, got %q", p.Errors)
+	}
+
+	_, err := decodeICMPHeader(bytes.NewReader(header[40:]), 6, false)
+	if !errors.Is(err, ErrDecodingRecord) {
+		t.Errorf("expected %v, got %v", ErrDecodingRecord, err)
+	}
+}
@@ -0,0 +1,682 @@
+// Command xdrgen generates the DecodeXDR and EncodeXDR methods of the sFlow records.
+//
+// It reads the record types registered in flowRecordTypes and counterRecordTypes of the records package
+// and the same struct tags as the reflection based decodeInto and Encode:
+//
+//	ignoreOnMarshal:"true"        the field is not part of the XDR representation
+//	lengthLookUp:"Field"          the number of elements of a slice is held by Field
+//	maxLength:"N"                 a slice holds at most N elements, MaximumRecordLength if not given
+//	ipVersion:"4" or "6"          the length of an IP address
+//	ipVersionLookUp:"Field"       the IP version (1 for IPv4, 2 for IPv6) is held by Field
+//
+// Further struct types, like the headers of sampled packets, are given with -types.
+// Records with fields the generator does not handle are left out and keep using reflection.
+// Run it with go generate in the records package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// registries are the maps of the records package holding the record types
+var registries = []string{"flowRecordTypes", "counterRecordTypes"}
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the records package")
+	output := flag.String("output", "xdr_gen.go", "file to write the generated code to")
+	types := flag.String("types", "", "comma separated struct types to generate besides the records")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("xdrgen: ")
+
+	g, err := newGenerator(*dir, *output)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names := g.recordTypes()
+	if *types != "" {
+		names = append(names, strings.Split(*types, ",")...)
+	}
+
+	for _, name := range names {
+		if err := g.generate(name); err != nil {
+			log.Printf("%s uses reflection: %s", name, err)
+		}
+	}
+
+	src, err := g.source()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generator struct {
+	pkg   string
+	files []*ast.File
+	types map[string]*ast.TypeSpec
+
+	// done holds the outcome of generating each type, the generated types are written in order
+	done  map[string]error
+	order []string
+	code  map[string][]byte
+}
+
+func newGenerator(dir, output string) (*generator, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected a single package in %s, found %d", dir, len(pkgs))
+	}
+
+	g := &generator{
+		types: map[string]*ast.TypeSpec{},
+		done:  map[string]error{},
+		code:  map[string][]byte{},
+	}
+
+	for name, pkg := range pkgs {
+		g.pkg = name
+		for _, file := range pkg.Files {
+			g.files = append(g.files, file)
+		}
+	}
+
+	for _, file := range g.files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				g.types[spec.Name.Name] = spec
+			}
+			return true
+		})
+	}
+
+	return g, nil
+}
+
+// registered is a record type of a registry with the constant it is registered with
+type registered struct {
+	constant string
+	name     string
+}
+
+// registered returns the types of the registry in the order of their declaration
+func (g *generator) registered(registry string) []registered {
+	var types []registered
+
+	for _, file := range g.files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.ValueSpec)
+			if !ok || len(spec.Names) != 1 || spec.Names[0].Name != registry || len(spec.Values) != 1 {
+				return true
+			}
+
+			if lit, ok := spec.Values[0].(*ast.CompositeLit); ok {
+				for _, elt := range lit.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if value, ok := kv.Value.(*ast.CompositeLit); ok {
+							if ident, ok := value.Type.(*ast.Ident); ok {
+								constant := ""
+								if key, ok := kv.Key.(*ast.Ident); ok {
+									constant = key.Name
+								}
+								types = append(types, registered{constant, ident.Name})
+							}
+						}
+					}
+				}
+			}
+			return false
+		})
+	}
+
+	return types
+}
+
+// recordTypes returns the names of the types registered in the record maps in the order of their declaration
+func (g *generator) recordTypes() []string {
+	var names []string
+
+	for _, registry := range registries {
+		for _, t := range g.registered(registry) {
+			names = append(names, t.name)
+		}
+	}
+
+	return names
+}
+
+// hasMethod returns whether the named type or a pointer to it has the method
+func (g *generator) hasMethod(typeName, method string) bool {
+	for _, file := range g.files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 || fn.Name.Name != method {
+				continue
+			}
+
+			recv := fn.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok && ident.Name == typeName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// writeRegistryDecoder writes a function decoding the records of the registry with a generated decoder
+// by their type, which spares DecodeFlow and DecodeCounter creating them by reflection
+func (g *generator) writeRegistryDecoder(buf *bytes.Buffer, registry, function, kind string) {
+	fmt.Fprintf(buf, "\n// %s decodes the %s records of the given type which have a generated decoder from r.\n", function, kind)
+	fmt.Fprintf(buf, "// It returns false for all other record types.\n")
+	fmt.Fprintf(buf, "func %s(r io.Reader, recordType uint32) (Record, bool, error) {\n", function)
+	fmt.Fprintf(buf, "switch recordType {\n")
+
+	for _, t := range g.generated(registry) {
+		fmt.Fprintf(buf, "case %s:\n", t.constant)
+		fmt.Fprintf(buf, "rec := %s{}\n", t.name)
+		fmt.Fprintf(buf, "_, err := rec.DecodeXDR(r)\n")
+		if g.hasMethod(t.name, "PostDecode") {
+			fmt.Fprintf(buf, "rec.PostDecode()\n")
+		}
+		fmt.Fprintf(buf, "return rec, true, err\n")
+	}
+
+	fmt.Fprintf(buf, "}\n\nreturn nil, false, nil\n}\n")
+}
+
+// writeRegistryBytesDecoder writes a function decoding the records of the registry with a generated decoder
+// in place by their type. The records are taken from their pools and put back by releaseXDRRecord.
+func (g *generator) writeRegistryBytesDecoder(buf *bytes.Buffer, registry, function, kind string) {
+	fmt.Fprintf(buf, "\n// %s decodes the %s records of the given type which have a generated decoder from b in place\n", function, kind)
+	fmt.Fprintf(buf, "// and returns the number of bytes read. It returns false for all other record types.\n")
+	fmt.Fprintf(buf, "func %s(b []byte, recordType uint32) (Record, int, bool, error) {\n", function)
+	fmt.Fprintf(buf, "x := xdrReader{b: b}\n\n")
+	fmt.Fprintf(buf, "switch recordType {\n")
+
+	for _, t := range g.generated(registry) {
+		fmt.Fprintf(buf, "case %s:\n", t.constant)
+		fmt.Fprintf(buf, "rec := %s.Get().(*%s)\n", poolName(t.name), t.name)
+		fmt.Fprintf(buf, "*rec = %s{}\n", t.name)
+		fmt.Fprintf(buf, "rec.decodeXDR(&x)\n")
+		if g.hasMethod(t.name, "PostDecode") {
+			fmt.Fprintf(buf, "rec.PostDecode()\n")
+		}
+		fmt.Fprintf(buf, "return rec, x.n, true, x.err\n")
+	}
+
+	fmt.Fprintf(buf, "}\n\nreturn nil, 0, false, nil\n}\n")
+}
+
+// writeRecordPools writes the pools of the records decoded in place and the function putting them back
+func (g *generator) writeRecordPools(buf *bytes.Buffer) {
+	var names []string
+	for _, registry := range registries {
+		for _, t := range g.generated(registry) {
+			names = append(names, t.name)
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// Records decoded in place are taken from these pools and put back by releaseXDRRecord\n")
+	fmt.Fprintf(buf, "var (\n")
+	for _, name := range names {
+		fmt.Fprintf(buf, "%s = sync.Pool{New: func() interface{} { return &%s{} }}\n", poolName(name), name)
+	}
+	fmt.Fprintf(buf, ")\n")
+
+	fmt.Fprintf(buf, "\n// releaseXDRRecord puts a record decoded in place back into its pool.\n")
+	fmt.Fprintf(buf, "// It returns false for all other records.\n")
+	fmt.Fprintf(buf, "func releaseXDRRecord(rec Record) bool {\n")
+	fmt.Fprintf(buf, "switch rec := rec.(type) {\n")
+	for _, name := range names {
+		fmt.Fprintf(buf, "case *%s:\n%s.Put(rec)\n", name, poolName(name))
+	}
+	fmt.Fprintf(buf, "default:\nreturn false\n}\n\nreturn true\n}\n")
+}
+
+// generated returns the types of the registry which have a generated decoder
+func (g *generator) generated(registry string) []registered {
+	var types []registered
+
+	for _, t := range g.registered(registry) {
+		if t.constant == "" || g.done[t.name] != nil {
+			continue
+		}
+		types = append(types, t)
+	}
+
+	return types
+}
+
+// poolName returns the name of the pool variable of the named type, like httpCounterPool for HTTPCounter
+func poolName(name string) string {
+	// The leading upper case letters are lowered, except for the first letter of the following word
+	n := 0
+	for n < len(name) && unicode.IsUpper(rune(name[n])) {
+		n++
+	}
+	if n > 1 && n < len(name) {
+		n--
+	}
+
+	return strings.ToLower(name[:n]) + name[n:] + "Pool"
+}
+
+// source returns the formatted code of all generated types
+func (g *generator) source() ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "// Code generated by xdrgen from the struct tags of the records. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package %s\n\n", g.pkg)
+	fmt.Fprintf(buf, "import (\n\t\"io\"\n\t\"sync\"\n)\n")
+
+	for _, name := range g.order {
+		buf.Write(g.code[name])
+	}
+
+	g.writeRegistryDecoder(buf, "flowRecordTypes", "decodeFlowXDR", "flow")
+	g.writeRegistryDecoder(buf, "counterRecordTypes", "decodeCounterXDR", "counter")
+	g.writeRegistryBytesDecoder(buf, "flowRecordTypes", "decodeFlowXDRBytes", "flow")
+	g.writeRegistryBytesDecoder(buf, "counterRecordTypes", "decodeCounterXDRBytes", "counter")
+	g.writeRecordPools(buf)
+
+	return format.Source(buf.Bytes())
+}
+
+// generate generates the methods of the named struct type and of the struct types it contains
+func (g *generator) generate(name string) error {
+	if err, found := g.done[name]; found {
+		return err
+	}
+
+	// Recursive types are not supported, this is overwritten once done
+	g.done[name] = fmt.Errorf("recursive type %s", name)
+
+	err := g.generateStruct(name)
+	g.done[name] = err
+	if err == nil {
+		g.order = append(g.order, name)
+	}
+
+	return err
+}
+
+// fieldKind is the way a field is decoded and encoded
+type fieldKind int
+
+const (
+	kindInteger fieldKind = iota
+	kindOpaque
+	kindIntegers
+	kindStructs
+	kindStruct
+	kindIP
+	kindBytes
+	kindHardwareAddr
+)
+
+// field describes a struct field in the XDR representation
+type field struct {
+	name   string
+	kind   fieldKind
+	basic  string // the underlying integer type of integers
+	named  string // the type of the field or of the slice elements if they are named
+	length string // the field holding the number of elements of slices
+	max    string // the maximum number of elements of slices
+	ip     string // the length or ipVersionLookUp field of IP addresses
+}
+
+func (g *generator) generateStruct(name string) error {
+	spec, found := g.types[name]
+	if !found {
+		return fmt.Errorf("type %s not found", name)
+	}
+
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return fmt.Errorf("type %s is not a struct", name)
+	}
+
+	var fields []field
+
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return fmt.Errorf("embedded field %s", typeString(f.Type))
+		}
+
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			value, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return err
+			}
+			tag = reflect.StructTag(value)
+		}
+
+		if tag.Get("ignoreOnMarshal") == "true" {
+			continue
+		}
+
+		for _, ident := range f.Names {
+			if !ident.IsExported() {
+				return fmt.Errorf("unexported field %s", ident.Name)
+			}
+
+			fd, err := g.field(ident.Name, f.Type, tag)
+			if err != nil {
+				return fmt.Errorf("field %s: %s", ident.Name, err)
+			}
+			fields = append(fields, fd)
+		}
+	}
+
+	receiver := "f"
+	if strings.HasSuffix(name, "Counter") {
+		receiver = "c"
+	}
+
+	buf := &bytes.Buffer{}
+	writeDecoder(buf, name, receiver, fields)
+	writeEncoder(buf, name, receiver, fields)
+	g.code[name] = buf.Bytes()
+
+	return nil
+}
+
+// field describes the field of the given type and struct tag
+func (g *generator) field(name string, typ ast.Expr, tag reflect.StructTag) (field, error) {
+	f := field{name: name}
+
+	switch t := typ.(type) {
+	case *ast.SelectorExpr:
+		if typeString(t) != "net.IP" {
+			return f, fmt.Errorf("unsupported type %s", typeString(t))
+		}
+
+		f.kind = kindIP
+		switch tag.Get("ipVersion") {
+		case "4":
+			f.ip = "4"
+		case "6":
+			f.ip = "16"
+		default:
+			lookup := tag.Get("ipVersionLookUp")
+			if lookup == "" {
+				return f, fmt.Errorf("IP address without ipVersion or ipVersionLookUp")
+			}
+			f.ip = lookup
+		}
+		return f, nil
+	case *ast.ArrayType:
+		return g.sliceField(f, t, tag)
+	case *ast.Ident:
+		if t.Name == "HardwareAddr" {
+			f.kind = kindHardwareAddr
+			return f, nil
+		}
+
+		if basic := g.integer(t.Name); basic != "" {
+			f.kind = kindInteger
+			f.basic = basic
+			if basic != t.Name {
+				f.named = t.Name
+			}
+			return f, nil
+		}
+
+		spec, found := g.types[t.Name]
+		if !found {
+			return f, fmt.Errorf("unsupported type %s", t.Name)
+		}
+
+		switch underlying := spec.Type.(type) {
+		case *ast.StructType:
+			if err := g.generate(t.Name); err != nil {
+				return f, err
+			}
+			f.kind = kindStruct
+			f.named = t.Name
+			return f, nil
+		case *ast.ArrayType:
+			f, err := g.sliceField(f, underlying, tag)
+			f.named = t.Name
+			return f, err
+		}
+	}
+
+	return f, fmt.Errorf("unsupported type %s", typeString(typ))
+}
+
+// sliceField describes a slice or array field
+func (g *generator) sliceField(f field, t *ast.ArrayType, tag reflect.StructTag) (field, error) {
+	elem, ok := t.Elt.(*ast.Ident)
+	if !ok {
+		return f, fmt.Errorf("unsupported type %s", typeString(t))
+	}
+
+	// Fixed length arrays of bytes
+	if t.Len != nil {
+		if g.integer(elem.Name) != "uint8" {
+			return f, fmt.Errorf("unsupported type %s", typeString(t))
+		}
+		f.kind = kindBytes
+		return f, nil
+	}
+
+	f.length = tag.Get("lengthLookUp")
+	if f.length == "" {
+		return f, fmt.Errorf("variable length slice without lengthLookUp")
+	}
+
+	f.max = "MaximumRecordLength"
+	if max := tag.Get("maxLength"); max != "" {
+		if n, err := strconv.Atoi(max); err != nil || n < 0 {
+			return f, fmt.Errorf("invalid maxLength %q", max)
+		}
+		f.max = max
+	}
+
+	switch g.integer(elem.Name) {
+	case "uint8":
+		f.kind = kindOpaque
+		return f, nil
+	case "uint32", "int32":
+		f.kind = kindIntegers
+		f.basic = g.integer(elem.Name)
+		if elem.Name != f.basic {
+			return f, fmt.Errorf("unsupported type %s", typeString(t))
+		}
+		return f, nil
+	}
+
+	if spec, found := g.types[elem.Name]; found {
+		if _, ok := spec.Type.(*ast.StructType); ok {
+			if err := g.generate(elem.Name); err != nil {
+				return f, err
+			}
+			f.kind = kindStructs
+			f.named = elem.Name
+			return f, nil
+		}
+	}
+
+	return f, fmt.Errorf("unsupported type %s", typeString(t))
+}
+
+// integer returns the underlying integer type of the named type or "" if it is not an integer
+func (g *generator) integer(name string) string {
+	switch name {
+	case "byte":
+		return "uint8"
+	case "uint8", "uint16", "uint32", "uint64", "int32", "int64":
+		return name
+	}
+
+	if spec, found := g.types[name]; found {
+		if ident, ok := spec.Type.(*ast.Ident); ok && ident.Name != name {
+			return g.integer(ident.Name)
+		}
+	}
+
+	return ""
+}
+
+func writeDecoder(buf *bytes.Buffer, name, recv string, fields []field) {
+	fmt.Fprintf(buf, "\n// DecodeXDR decodes %s from r and returns the number of bytes read\n", name)
+	fmt.Fprintf(buf, "func (%s *%s) DecodeXDR(r io.Reader) (int, error) {\n", recv, name)
+	fmt.Fprintf(buf, "x := xdrReader{r: r}\n")
+	fmt.Fprintf(buf, "%s.decodeXDR(&x)\n", recv)
+	fmt.Fprintf(buf, "return x.n, x.err\n}\n")
+
+	fmt.Fprintf(buf, "\nfunc (%s *%s) decodeXDR(x *xdrReader) {\n", recv, name)
+
+	for _, f := range fields {
+		v := recv + "." + f.name
+		length := fmt.Sprintf("x.length(%q, uint64(%s.%s), %s)", name+"."+f.name, recv, f.length, f.max)
+
+		switch f.kind {
+		case kindInteger:
+			if f.named != "" {
+				fmt.Fprintf(buf, "x.%s((*%s)(&%s))\n", f.basic, f.basic, v)
+			} else {
+				fmt.Fprintf(buf, "x.%s(&%s)\n", f.basic, v)
+			}
+		case kindOpaque:
+			if f.named != "" {
+				fmt.Fprintf(buf, "x.opaque((*[]byte)(&%s), %s)\n", v, length)
+			} else {
+				fmt.Fprintf(buf, "x.opaque(&%s, %s)\n", v, length)
+			}
+		case kindIntegers:
+			fmt.Fprintf(buf, "x.%ss(&%s, %s)\n", f.basic, v, length)
+		case kindStructs:
+			fmt.Fprintf(buf, "if n := %s; n > 0 {\n", length)
+			fmt.Fprintf(buf, "%s = make([]%s, n)\n", v, f.named)
+			fmt.Fprintf(buf, "for i := range %s {\n%s[i].decodeXDR(x)\n}\n}\n", v, v)
+		case kindStruct:
+			fmt.Fprintf(buf, "%s.decodeXDR(x)\n", v)
+		case kindIP:
+			if _, err := strconv.Atoi(f.ip); err == nil {
+				fmt.Fprintf(buf, "x.ip(&%s, %s)\n", v, f.ip)
+			} else {
+				fmt.Fprintf(buf, "x.ip(&%s, x.ipLength(uint64(%s.%s)))\n", v, recv, f.ip)
+			}
+		case kindBytes:
+			fmt.Fprintf(buf, "x.read(%s[:])\n", v)
+		case kindHardwareAddr:
+			fmt.Fprintf(buf, "x.hardwareAddr(&%s)\n", v)
+		}
+	}
+
+	fmt.Fprintf(buf, "}\n")
+}
+
+func writeEncoder(buf *bytes.Buffer, name, recv string, fields []field) {
+	fmt.Fprintf(buf, "\n// EncodeXDR writes the XDR representation of %s to w\n", name)
+	fmt.Fprintf(buf, "func (%s %s) EncodeXDR(w io.Writer) error {\n", recv, name)
+	fmt.Fprintf(buf, "x := &xdrWriter{w: w}\n")
+
+	// The length fields are written from the slices they hold the length of
+	lengthOf := map[string]string{}
+	for _, f := range fields {
+		if f.length != "" {
+			lengthOf[f.length] = f.name
+		}
+	}
+
+	for _, f := range fields {
+		v := recv + "." + f.name
+
+		if slice, found := lengthOf[f.name]; found && f.kind == kindInteger {
+			fmt.Fprintf(buf, "x.%s(%s(len(%s.%s)))\n", f.basic, f.basic, recv, slice)
+			continue
+		}
+
+		switch f.kind {
+		case kindOpaque, kindIntegers, kindStructs:
+			fmt.Fprintf(buf, "x.length(%q, len(%s), %s)\n", name+"."+f.name, v, f.max)
+		}
+
+		switch f.kind {
+		case kindInteger:
+			if f.named != "" {
+				fmt.Fprintf(buf, "x.%s(%s(%s))\n", f.basic, f.basic, v)
+			} else {
+				fmt.Fprintf(buf, "x.%s(%s)\n", f.basic, v)
+			}
+		case kindOpaque:
+			if f.named != "" {
+				fmt.Fprintf(buf, "x.opaque([]byte(%s))\n", v)
+			} else {
+				fmt.Fprintf(buf, "x.opaque(%s)\n", v)
+			}
+		case kindIntegers:
+			fmt.Fprintf(buf, "x.%ss(%s)\n", f.basic, v)
+		case kindStructs:
+			fmt.Fprintf(buf, "for _, e := range %s {\nx.encode(e)\n}\n", v)
+		case kindStruct:
+			fmt.Fprintf(buf, "x.encode(%s)\n", v)
+		case kindIP:
+			if _, err := strconv.Atoi(f.ip); err == nil {
+				fmt.Fprintf(buf, "x.ip(%s, %s)\n", v, f.ip)
+			} else {
+				fmt.Fprintf(buf, "x.ip(%s, x.ipLength(uint64(%s.%s)))\n", v, recv, f.ip)
+			}
+		case kindBytes:
+			fmt.Fprintf(buf, "x.write(%s[:])\n", v)
+		case kindHardwareAddr:
+			fmt.Fprintf(buf, "x.write(%s)\n", v)
+		}
+	}
+
+	fmt.Fprintf(buf, "return x.err\n}\n")
+}
+
+// typeString returns the source representation of a type expression
+func typeString(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + typeString(t.Elt)
+		}
+		if lit, ok := t.Len.(*ast.BasicLit); ok {
+			return "[" + lit.Value + "]" + typeString(t.Elt)
+		}
+		return "[...]" + typeString(t.Elt)
+	}
+	return fmt.Sprintf("%T", typ)
+}
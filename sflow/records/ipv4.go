@@ -0,0 +1,145 @@
+package records
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+)
+
+// IPv4 Fragmentation Flags, as found in IPv4Header.FragOff
+const (
+	IPv4FlagDontFragment  = 0x4000
+	IPv4FlagMoreFragments = 0x2000
+	IPv4FragmentOffset    = 0x1fff
+)
+
+// IPv4 Option Types (see https://www.iana.org/assignments/ip-parameters)
+const (
+	IPv4OptionEndOfList   = 0
+	IPv4OptionNOP         = 1
+	IPv4OptionRecordRoute = 7
+	IPv4OptionTimestamp   = 68
+	IPv4OptionSecurity    = 130
+	IPv4OptionLSRR        = 131
+	IPv4OptionStreamID    = 136
+	IPv4OptionSSRR        = 137
+	IPv4OptionRouterAlert = 148
+)
+
+// IPv4MinimumHeaderLength is the length of an IPv4 header without options in 32-bit words
+const IPv4MinimumHeaderLength = 5
+
+var ipv4OptionNames = map[uint32]string{
+	IPv4OptionRecordRoute: "RR",
+	IPv4OptionTimestamp:   "TS",
+	IPv4OptionSecurity:    "SEC",
+	IPv4OptionLSRR:        "LSRR",
+	IPv4OptionStreamID:    "SID",
+	IPv4OptionSSRR:        "SSRR",
+	IPv4OptionRouterAlert: "RTRALT",
+}
+
+// IPv4Header as found in RawPacketFlow.Header
+type IPv4Header struct {
+	VersionAndLen uint8  `json:"versionAndLen"`
+	Tos           uint8  `json:"tos"`
+	TotLen        uint16 `json:"totLen"`
+	ID            uint16 `json:"id"`
+	FragOff       uint16 `json:"fragOff"`
+	TTL           uint8  `json:"ttl"`
+	Protocol      uint8  `json:"protocol"`
+	Check         uint16 `json:"check"`
+	SrcAddr       net.IP `ipVersion:"4" json:"srcAddr"`
+	DstAddr       net.IP `ipVersion:"4" json:"dstAddr"`
+
+	DSCP           uint8        `ignoreOnMarshal:"true" json:"dscp"`
+	DSCPName       string       `ignoreOnMarshal:"true" json:"dscpName"`
+	ECN            uint8        `ignoreOnMarshal:"true" json:"ecn"`
+	ECNName        string       `ignoreOnMarshal:"true" json:"ecnName"`
+	HeaderLength   uint8        `ignoreOnMarshal:"true" json:"headerLength"` /* IHL, header length in 32-bit words */
+	DontFragment   bool         `ignoreOnMarshal:"true" json:"dontFragment"`
+	MoreFragments  bool         `ignoreOnMarshal:"true" json:"moreFragments"`
+	FragmentOffset uint16       `ignoreOnMarshal:"true" json:"fragmentOffset"` /* offset of the fragment in 8 octet units */
+	Options        []IPv4Option `ignoreOnMarshal:"true" json:"options,omitempty"`
+}
+
+// IPv4Option is an option found in the sampled part of an IPv4 header
+type IPv4Option struct {
+	Type uint8  `json:"type"`
+	Name string `json:"name"`
+	Data []byte `json:"data,omitempty"`
+}
+
+// IsFragment returns true if the header belongs to a fragmented packet
+func (ip IPv4Header) IsFragment() bool {
+	return ip.MoreFragments || ip.FragmentOffset != 0
+}
+
+// decodeIPv4Header decodes an IPv4 header including its options from h into ip, so h is positioned at the
+// start of the payload afterwards. The addresses and options refer to the sampled header of p.
+func decodeIPv4Header(h *bytes.Reader, p *DecodedPacket, ip *IPv4Header) error {
+	*ip = IPv4Header{}
+
+	x := p.headerReader(h)
+	ip.decodeXDR(&x)
+	if err := x.skip(h); err != nil {
+		return err
+	}
+
+	ip.DSCP, ip.ECN = ip.Tos>>2, ip.Tos&0x03
+	ip.DSCPName, ip.ECNName = DSCPName(ip.DSCP), ECNName(ip.ECN)
+	ip.HeaderLength = ip.VersionAndLen & 0x0f
+	ip.DontFragment = ip.FragOff&IPv4FlagDontFragment != 0
+	ip.MoreFragments = ip.FragOff&IPv4FlagMoreFragments != 0
+	ip.FragmentOffset = ip.FragOff & IPv4FragmentOffset
+
+	if ip.HeaderLength < IPv4MinimumHeaderLength {
+		return fmt.Errorf("sflow: invalid IPv4 header length: %d", ip.HeaderLength)
+	}
+
+	optionsLength := int(ip.HeaderLength-IPv4MinimumHeaderLength) * 4
+	if optionsLength == 0 {
+		return nil
+	}
+
+	// The sampled header may end within the options
+	options := p.next(h, optionsLength)
+
+	ip.decodeOptions(options)
+
+	if len(options) < optionsLength {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+// decodeOptions decodes the options in b, stopping at the end of the option list or at a truncated option
+func (ip *IPv4Header) decodeOptions(b []byte) {
+	for len(b) > 0 {
+		kind := b[0]
+
+		switch kind {
+		case IPv4OptionEndOfList:
+			return
+		case IPv4OptionNOP:
+			b = b[1:]
+			continue
+		}
+
+		if len(b) < 2 || b[1] < 2 || int(b[1]) > len(b) {
+			return
+		}
+
+		option := IPv4Option{
+			Type: kind,
+			Name: lookupName(ipv4OptionNames, uint32(kind)),
+		}
+		if b[1] > 2 {
+			option.Data = b[2:b[1]:b[1]]
+		}
+		ip.Options = append(ip.Options, option)
+
+		b = b[b[1]:]
+	}
+}
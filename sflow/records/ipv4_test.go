@@ -0,0 +1,73 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeIPv4Options(t *testing.T) {
+	header := []byte{
+		// IPv4 192.0.2.1 -> 224.0.0.22, IHL 6, DF, UDP
+		0x46, 0x00, 0x00, 0x24, 0x00, 0x01, 0x40, 0x00, 0x01, 0x11, 0x00, 0x00,
+		192, 0, 2, 1, 224, 0, 0, 22,
+		// Router Alert
+		0x94, 0x04, 0x00, 0x00,
+		// UDP 5000 -> 6000
+		0x13, 0x88, 0x17, 0x70, 0x00, 0x08, 0x00, 0x00,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, header)
+
+	ip := *p.network().IPv4
+	if ip.HeaderLength != 6 || !ip.DontFragment || ip.IsFragment() {
+		t.Errorf("unexpected IPv4 header %+v", ip)
+	}
+
+	expectedOptions := []IPv4Option{{Type: IPv4OptionRouterAlert, Name: "RTRALT", Data: []byte{0, 0}}}
+	if !reflect.DeepEqual(ip.Options, expectedOptions) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expectedOptions, ip.Options)
+	}
+
+	expected := UDPHeader{SrcPort: 5000, DstPort: 6000, Length: 8}
+	if udp := p.transport().UDP; !reflect.DeepEqual(udp, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, udp)
+	}
+}
+
+func TestDecodeIPv4Fragments(t *testing.T) {
+	first := []byte{
+		// IPv4 192.0.2.1 -> 198.51.100.7, MF, UDP
+		0x45, 0x00, 0x05, 0xdc, 0x00, 0x01, 0x20, 0x00, 0x40, 0x11, 0x00, 0x00,
+		192, 0, 2, 1, 198, 51, 100, 7,
+		// UDP 5000 -> 6000
+		0x13, 0x88, 0x17, 0x70, 0x07, 0xd0, 0x00, 0x00,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, first)
+
+	ip := *p.network().IPv4
+	if !ip.MoreFragments || ip.FragmentOffset != 0 || !ip.IsFragment() {
+		t.Errorf("unexpected IPv4 header %+v", ip)
+	}
+	if p.transport().UDP == nil {
+		t.Errorf("expected the first fragment to carry a UDP header, got %+v", p)
+	}
+
+	second := []byte{
+		// IPv4 192.0.2.1 -> 198.51.100.7, offset 185, UDP
+		0x45, 0x00, 0x00, 0x24, 0x00, 0x01, 0x00, 0xb9, 0x40, 0x11, 0x00, 0x00,
+		192, 0, 2, 1, 198, 51, 100, 7,
+		// Payload
+		0xde, 0xad, 0xbe, 0xef, 0xde, 0xad, 0xbe, 0xef,
+	}
+
+	p = decodeTestPacket(t, HeaderProtocolIPv4, second)
+
+	ip = *p.network().IPv4
+	if ip.MoreFragments || ip.FragmentOffset != 185 {
+		t.Errorf("unexpected IPv4 header %+v", ip)
+	}
+	if udp := p.transport().UDP; udp != nil {
+		t.Errorf("expected no UDP header in a non-initial fragment, got %+v", udp)
+	}
+}
@@ -0,0 +1,112 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+)
+
+// IPv6Header as found in RawPacketFlow.Header
+type IPv6Header struct {
+	VersionAndPriority uint8  `json:"versionAndPriority"`
+	Label1             uint8  `json:"label1"`
+	Label2             uint8  `json:"label2"`
+	Label3             uint8  `json:"label3"`
+	PayloadLength      uint16 `json:"payloadLength"`
+	NextHeader         uint8  `json:"nextHeader"`
+	TTL                uint8  `json:"ttl"`
+	SrcAddr            net.IP `ipVersion:"6" json:"srcAddr"`
+	DstAddr            net.IP `ipVersion:"6" json:"dstAddr"`
+
+	DSCP             uint8   `ignoreOnMarshal:"true" json:"dscp"`
+	DSCPName         string  `ignoreOnMarshal:"true" json:"dscpName"`
+	ECN              uint8   `ignoreOnMarshal:"true" json:"ecn"`
+	ECNName          string  `ignoreOnMarshal:"true" json:"ecnName"`
+	FlowLabel        uint32  `ignoreOnMarshal:"true" json:"flowLabel"`
+	Protocol         uint8   `ignoreOnMarshal:"true" json:"protocol"` /* upper layer protocol following the extension headers */
+	ExtensionHeaders []uint8 `ignoreOnMarshal:"true" json:"extensionHeaders,omitempty"`
+	MoreFragments    bool    `ignoreOnMarshal:"true" json:"moreFragments,omitempty"`
+	FragmentOffset   uint16  `ignoreOnMarshal:"true" json:"fragmentOffset,omitempty"` /* offset of the fragment in 8 octet units */
+}
+
+// IsFragment returns true if the header is followed by a fragment header
+func (ip IPv6Header) IsFragment() bool {
+	return ip.MoreFragments || ip.FragmentOffset != 0
+}
+
+// decodeIPv6Header decodes an IPv6 header from h into ip and skips its extension headers, so h is positioned
+// at the start of the upper layer header afterwards. It returns the protocol of the upper layer header.
+// The addresses refer to the sampled header of p.
+func decodeIPv6Header(h *bytes.Reader, p *DecodedPacket, ip *IPv6Header) (uint8, error) {
+	*ip = IPv6Header{}
+
+	x := p.headerReader(h)
+	ip.decodeXDR(&x)
+	if err := x.skip(h); err != nil {
+		return 0, err
+	}
+
+	// Version (4 bits), Traffic Class (8 bits) and Flow Label (20 bits)
+	trafficClass := ip.VersionAndPriority<<4 | ip.Label1>>4
+	ip.DSCP, ip.ECN = trafficClass>>2, trafficClass&0x03
+	ip.DSCPName, ip.ECNName = DSCPName(ip.DSCP), ECNName(ip.ECN)
+	ip.FlowLabel = uint32(ip.Label1&0x0f)<<16 | uint32(ip.Label2)<<8 | uint32(ip.Label3)
+
+	protocol, err := skipIPv6ExtensionHeaders(h, ip)
+	ip.Protocol = protocol
+	return protocol, err
+}
+
+// IPv6 Extension Header Types
+const (
+	IPv6ExtHopByHop    = 0
+	IPv6ExtRouting     = 43
+	IPv6ExtFragment    = 44
+	IPv6ExtDestination = 60
+)
+
+// skipIPv6ExtensionHeaders skips the extension headers following an IPv6Header and
+// records their types. It returns the protocol of the upper layer header.
+func skipIPv6ExtensionHeaders(h *bytes.Reader, ip *IPv6Header) (uint8, error) {
+	next := ip.NextHeader
+
+	for {
+		switch next {
+		case IPv6ExtHopByHop, IPv6ExtRouting, IPv6ExtFragment, IPv6ExtDestination, IPProtocolAH:
+		default:
+			return next, nil
+		}
+
+		ext := [2]uint8{}
+		if err := binary.Read(h, binary.BigEndian, &ext); err != nil {
+			return next, err
+		}
+		ip.ExtensionHeaders = append(ip.ExtensionHeaders, next)
+
+		// The length excludes the first 8 octets (4 octets for AH), of which we already read 2
+		length := (int64(ext[1]) + 1) * 8
+		switch next {
+		case IPv6ExtFragment:
+			var fragment uint16
+			if err := binary.Read(h, binary.BigEndian, &fragment); err != nil {
+				return next, err
+			}
+			ip.FragmentOffset = fragment >> 3
+			ip.MoreFragments = fragment&0x1 != 0
+
+			length = 6
+		case IPProtocolAH:
+			length = (int64(ext[1]) + 2) * 4
+		}
+
+		if _, err := h.Seek(length-2, io.SeekCurrent); err != nil {
+			return next, err
+		}
+		if h.Len() == 0 {
+			return next, io.ErrUnexpectedEOF
+		}
+
+		next = ext[0]
+	}
+}
@@ -0,0 +1,39 @@
+package records
+
+import (
+	"bytes"
+)
+
+// IPX Packet Types
+const (
+	IPXPacketTypeUnknown = 0
+	IPXPacketTypeRIP     = 1
+	IPXPacketTypeEcho    = 2
+	IPXPacketTypeError   = 3
+	IPXPacketTypePEP     = 4
+	IPXPacketTypeSPX     = 5
+	IPXPacketTypeNCP     = 17
+	IPXPacketTypeNetBIOS = 20
+)
+
+// IPXHeader as found in RawPacketFlow.Header
+type IPXHeader struct {
+	Checksum         uint16       `json:"checksum"`
+	Length           uint16       `json:"length"`
+	TransportControl uint8        `json:"transportControl"` /* hop count */
+	PacketType       uint8        `json:"packetType"`
+	DstNetwork       uint32       `json:"dstNetwork"`
+	DstNode          HardwareAddr `json:"dstNode"`
+	DstSocket        uint16       `json:"dstSocket"`
+	SrcNetwork       uint32       `json:"srcNetwork"`
+	SrcNode          HardwareAddr `json:"srcNode"`
+	SrcSocket        uint16       `json:"srcSocket"`
+}
+
+// dissectIPX decodes an IPX header from h into p
+func dissectIPX(h *bytes.Reader, p *DecodedPacket) error {
+	ipx := IPXHeader{}
+	_, err := decodeInto(h, &ipx)
+	p.network().IPX = &ipx
+	return err
+}
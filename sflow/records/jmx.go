@@ -0,0 +1,121 @@
+package records
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// JMXRuntimeCounter - TypeJMXRuntimeCounterRecord
+type JMXRuntimeCounter struct {
+	VMNameLen    uint32
+	VMName       XDRString `lengthLookUp:"VMNameLen" maxLength:"64"` /* vm name */
+	VMVendorLen  uint32
+	VMVendor     XDRString `lengthLookUp:"VMVendorLen" maxLength:"32"` /* the vendor for the instance */
+	VMVersionLen uint32
+	VMVersion    XDRString `lengthLookUp:"VMVersionLen" maxLength:"32"` /* the version for the instance */
+}
+
+func (c JMXRuntimeCounter) String() string {
+	type X JMXRuntimeCounter
+	x := X(c)
+	return fmt.Sprintf("JMXRuntimeCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c JMXRuntimeCounter) RecordName() string {
+	return "JMXRuntimeCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c JMXRuntimeCounter) RecordType() int {
+	return TypeJMXRuntimeCounterRecord
+}
+
+func (c JMXRuntimeCounter) calculateBinarySize() int {
+	var size int
+
+	size += binary.Size(c.VMNameLen)
+	size += paddedLength(len(c.VMName))
+	size += binary.Size(c.VMVendorLen)
+	size += paddedLength(len(c.VMVendor))
+	size += binary.Size(c.VMVersionLen)
+	size += paddedLength(len(c.VMVersion))
+
+	return size
+}
+
+func (c JMXRuntimeCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, c)
+}
+
+// JMXStatisticsCounter - TypeJMXStatisticsCounterRecord
+type JMXStatisticsCounter struct {
+	HeapInitial       uint64 /* initial heap memory requested */
+	HeapUsed          uint64 /* current heap memory usage */
+	HeapCommitted     uint64 /* heap memory currently committed */
+	HeapMax           uint64 /* max heap space */
+	NonHeapInitial    uint64 /* initial non heap memory requested */
+	NonHeapUsed       uint64 /* current non heap memory usage */
+	NonHeapCommitted  uint64 /* non heap memory currently committed */
+	NonHeapMax        uint64 /* max non-heap space */
+	GCCount           uint32 /* total number of collections that have occurred */
+	GCTime            uint32 /* approximate accumulated collection elapsed time in milliseconds */
+	ClassesLoaded     uint32 /* number of classes currently loaded in vm */
+	ClassesTotal      uint32 /* total number of classes loaded since vm started */
+	ClassesUnloaded   uint32 /* total number of classes unloaded since vm started */
+	CompilationTime   uint32 /* total accumulated time spent in compilation (in milliseconds) */
+	ThreadNumLive     uint32 /* current number of live threads */
+	ThreadNumDaemon   uint32 /* current number of live daemon threads */
+	ThreadNumStarted  uint32 /* total threads started since vm started */
+	FileDescOpenCount uint32 /* number of open file descriptors */
+	FileDescMaxCount  uint32 /* max number of file descriptors */
+}
+
+func (c JMXStatisticsCounter) String() string {
+	type X JMXStatisticsCounter
+	x := X(c)
+	return fmt.Sprintf("JMXStatisticsCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c JMXStatisticsCounter) RecordName() string {
+	return "JMXStatisticsCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c JMXStatisticsCounter) RecordType() int {
+	return TypeJMXStatisticsCounterRecord
+}
+
+func (c JMXStatisticsCounter) calculateBinarySize() int {
+	return binary.Size(c)
+}
+
+func (c JMXStatisticsCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, c)
+}
@@ -0,0 +1,78 @@
+package records
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeJMXRuntimeCounterRecord(t *testing.T) {
+	rec := JMXRuntimeCounter{
+		VMNameLen:    24,
+		VMName:       XDRString("OpenJDK 64-Bit Server VM"),
+		VMVendorLen:  18,
+		VMVendor:     XDRString("Oracle Corporation"),
+		VMVersionLen: 10,
+		VMVersion:    XDRString("11.0.9+11u"),
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 3 length fields and the strings padded to 24, 20 and 12 bytes
+	if size := rec.calculateBinarySize(); size != 12+24+20+12 {
+		t.Fatalf("expected size %d, got %d", 12+24+20+12, size)
+	}
+
+	if b.Len() != 8+rec.calculateBinarySize() {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+rec.calculateBinarySize(), b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeCounter(b, TypeJMXRuntimeCounterRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestEncodeDecodeJMXStatisticsCounterRecord(t *testing.T) {
+	rec := JMXStatisticsCounter{
+		HeapInitial:       256 << 20,
+		HeapUsed:          1 << 30,
+		HeapCommitted:     2 << 30,
+		HeapMax:           4 << 30,
+		NonHeapUsed:       96 << 20,
+		GCCount:           1234,
+		GCTime:            5678,
+		ClassesLoaded:     9000,
+		ThreadNumLive:     120,
+		ThreadNumDaemon:   100,
+		FileDescOpenCount: 512,
+		FileDescMaxCount:  65536,
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeCounter(b, TypeJMXStatisticsCounterRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
@@ -0,0 +1,148 @@
+package records
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+)
+
+var ErrRecordTypeMissing = errors.New("sflow: JSON record without recordType")
+
+// MarshalRecordJSON returns the JSON object of rec with its type in the "recordType" member,
+// which UnmarshalFlowJSON and UnmarshalCounterJSON read it back from.
+func MarshalRecordJSON(rec Record) ([]byte, error) {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) < 2 || b[0] != '{' {
+		return nil, fmt.Errorf("sflow: record %s is not a JSON object", rec.RecordName())
+	}
+
+	buf := bytes.NewBufferString(`{"recordType":`)
+	buf.WriteString(strconv.Itoa(rec.RecordType()))
+	if len(b) > 2 {
+		buf.WriteByte(',')
+	}
+	buf.Write(b[1:])
+
+	return buf.Bytes(), nil
+}
+
+// RecordTypeJSON returns the "recordType" member of a JSON record
+func RecordTypeJSON(data []byte) (uint32, error) {
+	var discriminator struct {
+		RecordType *uint32 `json:"recordType"`
+	}
+
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return 0, err
+	}
+
+	if discriminator.RecordType == nil {
+		return 0, ErrRecordTypeMissing
+	}
+
+	return *discriminator.RecordType, nil
+}
+
+// UnmarshalFlowJSON returns the flow record of a JSON object written by MarshalRecordJSON
+func UnmarshalFlowJSON(data []byte) (Record, error) {
+	recordType, err := RecordTypeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	prototype, found := flowRecordTypes[recordType]
+	if !found {
+		return nil, fmt.Errorf("flow record type %d: %w", recordType, ErrUnknownRecordType)
+	}
+
+	return UnmarshalRecordJSON(prototype.(Record), data)
+}
+
+// UnmarshalCounterJSON returns the counter record of a JSON object written by MarshalRecordJSON
+func UnmarshalCounterJSON(data []byte) (Record, error) {
+	recordType, err := RecordTypeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	prototype, found := counterRecordTypes[recordType]
+	if !found {
+		return nil, fmt.Errorf("counter record type %d: %w", recordType, ErrUnknownRecordType)
+	}
+
+	return UnmarshalRecordJSON(prototype.(Record), data)
+}
+
+// UnmarshalRecordJSON returns a record of the type of prototype read from JSON. The record is completed as if it
+// was decoded: the fields given by lengthLookUp tags are set from the length of their values, IPv4 addresses
+// are held in 4 bytes and the values calculated by PostDecode are set.
+func UnmarshalRecordJSON(prototype Record, data []byte) (Record, error) {
+	value := reflect.New(reflect.TypeOf(prototype))
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return nil, err
+	}
+
+	completeJSON(value.Elem())
+
+	if rec, ok := value.Interface().(PostDecoder); ok {
+		rec.PostDecode()
+	}
+
+	return value.Elem().Interface().(Record), nil
+}
+
+// completeJSON sets the lengths and normalizes the slices and IP addresses of the struct v like the decoder does
+func completeJSON(v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := v.Field(i)
+
+		if sf.Tag.Get("ignoreOnMarshal") == "true" || !field.CanSet() {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.Struct:
+			completeJSON(field)
+		case reflect.Slice:
+			if field.Type() == reflect.TypeOf(net.IP{}) {
+				ipv4 := sf.Tag.Get("ipVersion") == "4"
+				if lookup := sf.Tag.Get("ipVersionLookUp"); lookup != "" {
+					ipv4 = v.FieldByName(lookup).Uint() == 1
+				}
+
+				if ip4 := net.IP(field.Bytes()).To4(); ipv4 && ip4 != nil {
+					field.SetBytes(ip4)
+				}
+				continue
+			}
+
+			lookup := sf.Tag.Get("lengthLookUp")
+			if lookup == "" {
+				continue
+			}
+
+			// The decoder leaves empty slices nil
+			if field.Len() == 0 {
+				field.Set(reflect.Zero(field.Type()))
+			}
+			v.FieldByName(lookup).SetUint(uint64(field.Len()))
+
+			if field.Type().Elem().Kind() == reflect.Struct {
+				for x := 0; x < field.Len(); x++ {
+					completeJSON(field.Index(x))
+				}
+			}
+		}
+	}
+}
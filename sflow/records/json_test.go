@@ -0,0 +1,94 @@
+package records
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRecordJSONRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, rec := range testGeneratedRecords(t) {
+		recordType := uint32(rec.(Record).RecordType())
+
+		// Flow and counter records share some of their types
+		decode, unmarshal := DecodeCounter, UnmarshalCounterJSON
+		if reflect.TypeOf(flowRecordTypes[recordType]) == reflect.TypeOf(rec) {
+			decode, unmarshal = DecodeFlow, UnmarshalFlowJSON
+		}
+
+		for i := 0; i < 20; i++ {
+			value := reflect.New(reflect.TypeOf(rec)).Elem()
+			testFill(rnd, value)
+
+			// Decoding sets the values calculated from the encoded fields
+			buf := &bytes.Buffer{}
+			if err := Encode(buf, value.Interface()); err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+			expected, err := decode(buf, recordType)
+			if err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+
+			b, err := MarshalRecordJSON(expected)
+			if err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+
+			decoded, err := unmarshal(b)
+			if err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+
+			if !reflect.DeepEqual(decoded, expected) {
+				t.Fatalf("%T: expected\n%+#v\n, got\n%+#v\nfrom %s", rec, expected, decoded, b)
+			}
+		}
+	}
+}
+
+func TestRecordJSONErrors(t *testing.T) {
+	for _, c := range []struct {
+		json     string
+		expected error
+	}{
+		{`{"SourceVlan": 1}`, ErrRecordTypeMissing},
+		{`{"recordType": 9999}`, ErrUnknownRecordType},
+	} {
+		if _, err := UnmarshalFlowJSON([]byte(c.json)); !errors.Is(err, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.json, c.expected, err)
+		}
+	}
+}
+
+func TestRawPacketFlowJSON(t *testing.T) {
+	// An Ethernet header of an IPv4 packet whose IP header was not sampled
+	header := `"////////AgAAAAABCAA="`
+
+	rec, err := UnmarshalFlowJSON([]byte(`{"recordType": 1, "Protocol": 1, "FrameLength": 64, "Header": ` + header + `}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := rec.(RawPacketFlow)
+	if f.HeaderSize != 14 {
+		t.Errorf("expected the header size 14, got %d", f.HeaderSize)
+	}
+	if f.DecodedHeader == nil || f.DecodedHeader.Link == nil || len(f.DecodedHeader.Errors) == 0 {
+		t.Errorf("expected the decoded Ethernet header and the error decoding the IP header, got %+v", f.DecodedHeader)
+	}
+
+	for _, c := range []string{
+		`{"recordType": 1, "Protocol": 1, "FrameLength": 64, "HeaderSize": 16, "Header": ` + header + `}`,
+		`{"recordType": 1, "Protocol": 1, "FrameLength": 64, "HeaderSize": 0, "Header": ` + header + `}`,
+		`{"recordType": 1, "Protocol": 1, "FrameLength": 16, "Stripped": 4, "Header": ` + header + `}`,
+	} {
+		if _, err := UnmarshalFlowJSON([]byte(c)); !errors.Is(err, ErrDecodingRecord) {
+			t.Errorf("%s: expected %v, got %v", c, ErrDecodingRecord, err)
+		}
+	}
+}
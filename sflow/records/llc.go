@@ -0,0 +1,102 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// IEEE 802.2 LLC Service Access Points
+const (
+	LLCSAPSTP  = 0x42
+	LLCSAPSNAP = 0xaa
+	LLCSAPIPX  = 0xe0
+	LLCSAPISO  = 0xfe // followed by a Network Layer Protocol ID
+	LLCSAPRaw  = 0xff // Novell "raw" 802.3 frames carry IPX without an LLC header
+)
+
+// LLCHeader as found in RawPacketFlow.Header
+type LLCHeader struct {
+	DSAP    uint8  `json:"dsap"`
+	SSAP    uint8  `json:"ssap"`
+	Control uint16 `ignoreOnMarshal:"true" json:"control"` /* 8 bits for unnumbered frames, 16 bits otherwise */
+}
+
+// SNAPHeader as found in RawPacketFlow.Header following an LLCHeader
+type SNAPHeader struct {
+	OUI  [3]uint8 `json:"oui"`
+	Type uint16   `json:"type"`
+}
+
+// decodeLLC decodes the 802.2 LLC header (and SNAP extension) of an IEEE 802.3 or other IEEE 802 frame.
+// IPX and IP payloads are decoded as well.
+func decodeLLC(h *bytes.Reader, p *DecodedPacket) error {
+	llc := LLCHeader{}
+
+	if _, err := decodeInto(h, &llc); err != nil {
+		return err
+	}
+
+	if llc.DSAP == LLCSAPRaw && llc.SSAP == LLCSAPRaw {
+		// The IPX checksum (always 0xffff) takes the place of the LLC header
+		if _, err := h.Seek(-2, io.SeekCurrent); err != nil {
+			return err
+		}
+
+		ipx := IPXHeader{}
+		_, err := decodeInto(h, &ipx)
+		p.network().IPX = &ipx
+		return err
+	}
+
+	p.link().LLC = &llc
+
+	control, err := h.ReadByte()
+	if err != nil {
+		return err
+	}
+	llc.Control = uint16(control)
+
+	// Information and supervisory frames use a 16 bit control field
+	if control&0x03 != 0x03 {
+		next, err := h.ReadByte()
+		if err != nil {
+			return err
+		}
+		llc.Control = llc.Control<<8 | uint16(next)
+	}
+
+	switch {
+	case llc.DSAP == LLCSAPSNAP && llc.SSAP == LLCSAPSNAP:
+		return decodeSNAP(h, p)
+	case llc.DSAP == LLCSAPISO && llc.SSAP == LLCSAPISO:
+		nlpid, err := h.ReadByte()
+		if err != nil {
+			return err
+		}
+		return decodeNLPID(nlpid, h, p)
+	case llc.DSAP == LLCSAPIPX && llc.SSAP == LLCSAPIPX:
+		ipx := IPXHeader{}
+		_, err := decodeInto(h, &ipx)
+		p.network().IPX = &ipx
+		return err
+	}
+
+	return nil
+}
+
+// decodeSNAP decodes a SNAP header and, if it identifies an EtherType, the following payload
+func decodeSNAP(h *bytes.Reader, p *DecodedPacket) error {
+	snap := SNAPHeader{}
+	if err := binary.Read(h, binary.BigEndian, &snap); err != nil {
+		return err
+	}
+	p.link().SNAP = &snap
+
+	// An OUI of zero means Type is an EtherType
+	if snap.OUI == [3]uint8{} {
+		return decodeEtherType(snap.Type, h, p)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeLLCSNAP(t *testing.T) {
+	header := []byte{
+		// IEEE 802.3, length 36
+		0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0x00, 0x24,
+		// LLC, SNAP, UI
+		0xaa, 0xaa, 0x03,
+		// SNAP, IPv4
+		0x00, 0x00, 0x00, 0x08, 0x00,
+		// IPv4 192.0.2.1 -> 198.51.100.7, UDP
+		0x45, 0x00, 0x00, 0x1c, 0x00, 0x01, 0x00, 0x00, 0x40, 0x11, 0x00, 0x00,
+		192, 0, 2, 1, 198, 51, 100, 7,
+		// UDP 5000 -> 6000
+		0x13, 0x88, 0x17, 0x70, 0x00, 0x08, 0x00, 0x00,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolEthernetISO8023, header)
+
+	expectedLLC := LLCHeader{DSAP: LLCSAPSNAP, SSAP: LLCSAPSNAP, Control: 0x03}
+	if llc := p.link().LLC; !reflect.DeepEqual(llc, &expectedLLC) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expectedLLC, llc)
+	}
+
+	expectedSNAP := SNAPHeader{Type: HeaderTypeIPv4}
+	if snap := p.link().SNAP; !reflect.DeepEqual(snap, &expectedSNAP) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expectedSNAP, snap)
+	}
+
+	if p.transport().UDP == nil {
+		t.Errorf("expected the SNAP payload to be decoded, got %+v", p)
+	}
+}
+
+func TestDecodeNovellRawIPX(t *testing.T) {
+	header := []byte{
+		// IEEE 802.3, length 30
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x00, 0x1e,
+		// IPX, RIP
+		0xff, 0xff, 0x00, 0x1e, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x04, 0x53,
+		0x00, 0x00, 0x00, 0x02, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x04, 0x53,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolEthernetISO8023, header)
+
+	expected := IPXHeader{
+		Checksum:   0xffff,
+		Length:     30,
+		PacketType: IPXPacketTypeRIP,
+		DstNetwork: 1,
+		DstNode:    HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		DstSocket:  0x0453,
+		SrcNetwork: 2,
+		SrcNode:    HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+		SrcSocket:  0x0453,
+	}
+
+	if ipx := p.network().IPX; !reflect.DeepEqual(ipx, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, ipx)
+	}
+	if llc := p.link().LLC; llc != nil {
+		t.Errorf("expected no LLC header in a raw 802.3 frame, got %+v", llc)
+	}
+}
+
+func TestDecodeAAL5(t *testing.T) {
+	// LLC, SNAP, IPv4
+	header := append([]byte{0xaa, 0xaa, 0x03, 0x00, 0x00, 0x00, 0x08, 0x00}, testIPv4UDPPacket...)
+
+	p := decodeTestPacket(t, HeaderProtocolAAL5, header)
+	if udp := p.transport().UDP; !reflect.DeepEqual(udp, &testIPv4UDPHeader) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", testIPv4UDPHeader, udp)
+	}
+
+	p = decodeTestPacket(t, HeaderProtocolAAL5IP, testIPv4UDPPacket)
+	if udp := p.transport().UDP; !reflect.DeepEqual(udp, &testIPv4UDPHeader) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", testIPv4UDPHeader, udp)
+	}
+}
@@ -0,0 +1,110 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+)
+
+// LLDP TLV Types (see IEEE 802.1AB)
+const (
+	LLDPTLVEnd               = 0
+	LLDPTLVChassisID         = 1
+	LLDPTLVPortID            = 2
+	LLDPTLVTTL               = 3
+	LLDPTLVPortDescription   = 4
+	LLDPTLVSystemName        = 5
+	LLDPTLVSystemDescription = 6
+)
+
+// LLDP Chassis ID Subtypes
+const (
+	LLDPChassisIDSubtypeMACAddress     = 4
+	LLDPChassisIDSubtypeNetworkAddress = 5
+)
+
+// LLDP Port ID Subtypes
+const (
+	LLDPPortIDSubtypeMACAddress     = 3
+	LLDPPortIDSubtypeNetworkAddress = 4
+)
+
+// LLDPHeader holds the identifying TLVs of an LLDPDU as found in RawPacketFlow.Header
+// TLVs beyond the end of the sampled header are missing.
+type LLDPHeader struct {
+	ChassisIDSubtype  uint8  `json:"chassisIDSubtype"`
+	ChassisID         string `json:"chassisID"`
+	PortIDSubtype     uint8  `json:"portIDSubtype"`
+	PortID            string `json:"portID"`
+	TTL               uint16 `json:"ttl"`
+	PortDescription   string `json:"portDescription,omitempty"`
+	SystemName        string `json:"systemName,omitempty"`
+	SystemDescription string `json:"systemDescription,omitempty"`
+}
+
+// decodeLLDPHeader decodes the TLVs of an LLDPDU
+func decodeLLDPHeader(h *bytes.Reader) (LLDPHeader, error) {
+	lldp := LLDPHeader{}
+
+	for {
+		// 7 bits type and 9 bits length
+		var typeLen uint16
+		if err := binary.Read(h, binary.BigEndian, &typeLen); err != nil {
+			return lldp, err
+		}
+
+		tlvType := typeLen >> 9
+		if tlvType == LLDPTLVEnd {
+			return lldp, nil
+		}
+
+		value := make([]byte, typeLen&0x01ff)
+		if _, err := io.ReadFull(h, value); err != nil {
+			return lldp, err
+		}
+
+		switch tlvType {
+		case LLDPTLVChassisID:
+			if len(value) > 1 {
+				lldp.ChassisIDSubtype = value[0]
+				lldp.ChassisID = lldpID(value[1:], value[0] == LLDPChassisIDSubtypeMACAddress, value[0] == LLDPChassisIDSubtypeNetworkAddress)
+			}
+		case LLDPTLVPortID:
+			if len(value) > 1 {
+				lldp.PortIDSubtype = value[0]
+				lldp.PortID = lldpID(value[1:], value[0] == LLDPPortIDSubtypeMACAddress, value[0] == LLDPPortIDSubtypeNetworkAddress)
+			}
+		case LLDPTLVTTL:
+			if len(value) == 2 {
+				lldp.TTL = binary.BigEndian.Uint16(value)
+			}
+		case LLDPTLVPortDescription:
+			lldp.PortDescription = string(value)
+		case LLDPTLVSystemName:
+			lldp.SystemName = string(value)
+		case LLDPTLVSystemDescription:
+			lldp.SystemDescription = string(value)
+		}
+	}
+}
+
+// lldpID formats a chassis or port ID according to its subtype
+func lldpID(id []byte, mac, networkAddress bool) string {
+	switch {
+	case mac && len(id) == 6:
+		return net.HardwareAddr(id).String()
+	case networkAddress && len(id) == 1+net.IPv4len && id[0] == 1,
+		networkAddress && len(id) == 1+net.IPv6len && id[0] == 2:
+		// Network addresses are prefixed with their IANA address family
+		return net.IP(id[1:]).String()
+	}
+	return string(id)
+}
+
+// dissectLLDP decodes a LLDPDU from h into p
+func dissectLLDP(h *bytes.Reader, p *DecodedPacket) error {
+	lldp, err := decodeLLDPHeader(h)
+	p.network().LLDP = &lldp
+	return err
+}
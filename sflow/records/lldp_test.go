@@ -0,0 +1,38 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeLLDP(t *testing.T) {
+	header := []byte{
+		// Ethernet
+		0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x88, 0xcc,
+		// Chassis ID, MAC address
+		0x02, 0x07, 0x04, 0x00, 0x11, 0x22, 0x33, 0x44, 0x00,
+		// Port ID, interface name
+		0x04, 0x06, 0x05, 'e', 't', 'h', '1', '2',
+		// TTL
+		0x06, 0x02, 0x00, 0x78,
+		// System name
+		0x0a, 0x05, 's', 'w', '-', '0', '1',
+		// End of LLDPDU
+		0x00, 0x00,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolEthernetISO8023, header)
+
+	expected := LLDPHeader{
+		ChassisIDSubtype: LLDPChassisIDSubtypeMACAddress,
+		ChassisID:        "00:11:22:33:44:00",
+		PortIDSubtype:    5,
+		PortID:           "eth12",
+		TTL:              120,
+		SystemName:       "sw-01",
+	}
+
+	if lldp := p.network().LLDP; !reflect.DeepEqual(lldp, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, lldp)
+	}
+}
@@ -0,0 +1,232 @@
+package records
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Memcache Protocols
+const (
+	MemcacheProtocolOther  = 0
+	MemcacheProtocolASCII  = 1
+	MemcacheProtocolBinary = 2
+)
+
+var memcacheProtocolNames = map[uint32]string{
+	MemcacheProtocolOther:  "OTHER",
+	MemcacheProtocolASCII:  "ASCII",
+	MemcacheProtocolBinary: "BINARY",
+}
+
+// Memcache Commands
+const (
+	MemcacheCmdOther     = 0
+	MemcacheCmdSet       = 1
+	MemcacheCmdAdd       = 2
+	MemcacheCmdReplace   = 3
+	MemcacheCmdAppend    = 4
+	MemcacheCmdPrepend   = 5
+	MemcacheCmdCas       = 6
+	MemcacheCmdGet       = 7
+	MemcacheCmdGets      = 8
+	MemcacheCmdIncr      = 9
+	MemcacheCmdDecr      = 10
+	MemcacheCmdTouch     = 11
+	MemcacheCmdStats     = 12
+	MemcacheCmdFlush     = 13
+	MemcacheCmdVersion   = 14
+	MemcacheCmdQuit      = 15
+	MemcacheCmdVerbosity = 16
+	MemcacheCmdDelete    = 17
+)
+
+var memcacheCmdNames = map[uint32]string{
+	MemcacheCmdOther:     "OTHER",
+	MemcacheCmdSet:       "SET",
+	MemcacheCmdAdd:       "ADD",
+	MemcacheCmdReplace:   "REPLACE",
+	MemcacheCmdAppend:    "APPEND",
+	MemcacheCmdPrepend:   "PREPEND",
+	MemcacheCmdCas:       "CAS",
+	MemcacheCmdGet:       "GET",
+	MemcacheCmdGets:      "GETS",
+	MemcacheCmdIncr:      "INCR",
+	MemcacheCmdDecr:      "DECR",
+	MemcacheCmdTouch:     "TOUCH",
+	MemcacheCmdStats:     "STATS",
+	MemcacheCmdFlush:     "FLUSH",
+	MemcacheCmdVersion:   "VERSION",
+	MemcacheCmdQuit:      "QUIT",
+	MemcacheCmdVerbosity: "VERBOSITY",
+	MemcacheCmdDelete:    "DELETE",
+}
+
+// Memcache Status Codes
+const (
+	MemcacheStatusUnknown     = 0
+	MemcacheStatusOK          = 1
+	MemcacheStatusError       = 2
+	MemcacheStatusClientError = 3
+	MemcacheStatusServerError = 4
+	MemcacheStatusStored      = 5
+	MemcacheStatusNotStored   = 6
+	MemcacheStatusExists      = 7
+	MemcacheStatusNotFound    = 8
+	MemcacheStatusDeleted     = 9
+)
+
+var memcacheStatusNames = map[uint32]string{
+	MemcacheStatusUnknown:     "UNKNOWN",
+	MemcacheStatusOK:          "OK",
+	MemcacheStatusError:       "ERROR",
+	MemcacheStatusClientError: "CLIENT_ERROR",
+	MemcacheStatusServerError: "SERVER_ERROR",
+	MemcacheStatusStored:      "STORED",
+	MemcacheStatusNotStored:   "NOT_STORED",
+	MemcacheStatusExists:      "EXISTS",
+	MemcacheStatusNotFound:    "NOT_FOUND",
+	MemcacheStatusDeleted:     "DELETED",
+}
+
+// MemcacheOperationFlow - TypeMemcacheOperationFlowRecord
+type MemcacheOperationFlow struct {
+	Protocol     uint32
+	Cmd          uint32
+	KeyLen       uint32
+	Key          XDRString `lengthLookUp:"KeyLen" maxLength:"255"` /* key used to store/retrieve data */
+	NKeys        uint32    /* number of keys (including sampled key) */
+	ValueBytes   uint32    /* size of the value (in bytes) */
+	Duration     uint32    /* duration of the operation (in microseconds) */
+	Status       uint32
+	ProtocolName string `ignoreOnMarshal:"true"`
+	CmdName      string `ignoreOnMarshal:"true"`
+	StatusName   string `ignoreOnMarshal:"true"`
+}
+
+func (f MemcacheOperationFlow) String() string {
+	type X MemcacheOperationFlow
+	x := X(f)
+	return fmt.Sprintf("MemcacheOperationFlow: %+v", x)
+}
+
+// RecordName returns the Name of this flow record
+func (f MemcacheOperationFlow) RecordName() string {
+	return "MemcacheOperationFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f MemcacheOperationFlow) RecordType() int {
+	return TypeMemcacheOperationFlowRecord
+}
+
+func (f MemcacheOperationFlow) calculateBinarySize() int {
+	var size int
+
+	size += binary.Size(f.Protocol)
+	size += binary.Size(f.Cmd)
+	size += binary.Size(f.KeyLen)
+	size += paddedLength(len(f.Key))
+	size += binary.Size(f.NKeys)
+	size += binary.Size(f.ValueBytes)
+	size += binary.Size(f.Duration)
+	size += binary.Size(f.Status)
+
+	return size
+}
+
+func (f *MemcacheOperationFlow) PostDecode() error {
+	f.ProtocolName = lookupName(memcacheProtocolNames, f.Protocol)
+	f.CmdName = lookupName(memcacheCmdNames, f.Cmd)
+	f.StatusName = lookupName(memcacheStatusNames, f.Status)
+
+	return nil
+}
+
+func (f MemcacheOperationFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
+}
+
+// MemcacheCounter - TypeMemcacheCounterRecord
+// See the memcached protocol.txt for the meaning of the counters.
+type MemcacheCounter struct {
+	CmdSet               uint32
+	CmdTouch             uint32
+	CmdFlush             uint32
+	GetHits              uint32
+	GetMisses            uint32
+	DeleteHits           uint32
+	DeleteMisses         uint32
+	IncrHits             uint32
+	IncrMisses           uint32
+	DecrHits             uint32
+	DecrMisses           uint32
+	CasHits              uint32
+	CasMisses            uint32
+	CasBadval            uint32
+	AuthCmds             uint32
+	AuthErrors           uint32
+	Threads              uint32
+	ConnYields           uint32
+	ListenDisabledNum    uint32
+	CurrConnections      uint32
+	RejectedConnections  uint32
+	TotalConnections     uint32
+	ConnectionStructures uint32
+	Evictions            uint32
+	Reclaimed            uint32
+	CurrItems            uint32
+	TotalItems           uint32
+	BytesRead            uint64
+	BytesWritten         uint64
+	Bytes                uint64
+	LimitMaxbytes        uint64
+}
+
+func (c MemcacheCounter) String() string {
+	type X MemcacheCounter
+	x := X(c)
+	return fmt.Sprintf("MemcacheCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c MemcacheCounter) RecordName() string {
+	return "MemcacheCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c MemcacheCounter) RecordType() int {
+	return TypeMemcacheCounterRecord
+}
+
+func (c MemcacheCounter) calculateBinarySize() int {
+	return binary.Size(c)
+}
+
+func (c MemcacheCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, c)
+}
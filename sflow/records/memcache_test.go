@@ -0,0 +1,72 @@
+package records
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeMemcacheOperationFlowRecord(t *testing.T) {
+	rec := MemcacheOperationFlow{
+		Protocol:     MemcacheProtocolASCII,
+		Cmd:          MemcacheCmdGet,
+		KeyLen:       14,
+		Key:          XDRString("session:123456"),
+		NKeys:        1,
+		ValueBytes:   512,
+		Duration:     90,
+		Status:       MemcacheStatusNotFound,
+		ProtocolName: "ASCII",
+		CmdName:      "GET",
+		StatusName:   "NOT_FOUND",
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+rec.calculateBinarySize() {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+rec.calculateBinarySize(), b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeFlow(b, TypeMemcacheOperationFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestEncodeDecodeMemcacheCounterRecord(t *testing.T) {
+	rec := MemcacheCounter{
+		CmdSet:        100,
+		GetHits:       900,
+		GetMisses:     100,
+		CurrItems:     12345,
+		BytesRead:     1 << 33,
+		LimitMaxbytes: 64 << 30,
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeCounter(b, TypeMemcacheCounterRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
@@ -0,0 +1,94 @@
+package records
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Navigation Types (see http://www.w3.org/TR/navigation-timing/#performancenavigation)
+const (
+	NavTypeNavigate    = 0
+	NavTypeReload      = 1
+	NavTypeBackForward = 2
+	NavTypeReserved    = 255
+)
+
+var navTypeNames = map[uint32]string{
+	NavTypeNavigate:    "navigate",
+	NavTypeReload:      "reload",
+	NavTypeBackForward: "back_forward",
+	NavTypeReserved:    "reserved",
+}
+
+// ExtendedNavTimingFlow - TypeExtendedNavTimingFlowRecord
+// Real user page timing as reported by the W3C Navigation Timing API.
+type ExtendedNavTimingFlow struct {
+	Type                       uint32 /* PerformanceNavigation */
+	RedirectCount              uint32
+	NavigationStart            uint32 /* PerformanceTiming */
+	UnloadEventStart           uint32
+	UnloadEventEnd             uint32
+	RedirectStart              uint32
+	RedirectEnd                uint32
+	FetchStart                 uint32
+	DomainLookupStart          uint32
+	DomainLookupEnd            uint32
+	ConnectStart               uint32
+	ConnectEnd                 uint32
+	SecureConnectionStart      uint32
+	RequestStart               uint32
+	ResponseStart              uint32
+	ResponseEnd                uint32
+	DomLoading                 uint32
+	DomInteractive             uint32
+	DomContentLoadedEventStart uint32
+	DomContentLoadedEventEnd   uint32
+	DomComplete                uint32
+	LoadEventStart             uint32
+	LoadEventEnd               uint32
+	TypeName                   string `ignoreOnMarshal:"true"`
+}
+
+func (f ExtendedNavTimingFlow) String() string {
+	type X ExtendedNavTimingFlow
+	x := X(f)
+	return fmt.Sprintf("ExtendedNavTimingFlow: %+v", x)
+}
+
+// RecordName returns the Name of this flow record
+func (f ExtendedNavTimingFlow) RecordName() string {
+	return "ExtendedNavTimingFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f ExtendedNavTimingFlow) RecordType() int {
+	return TypeExtendedNavTimingFlowRecord
+}
+
+func (f ExtendedNavTimingFlow) calculateBinarySize() int {
+	// All fields but the TypeName are 32 bit integers
+	return 23 * 4
+}
+
+func (f *ExtendedNavTimingFlow) PostDecode() error {
+	f.TypeName = lookupName(navTypeNames, f.Type)
+
+	return nil
+}
+
+func (f ExtendedNavTimingFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
+}
@@ -0,0 +1,55 @@
+package records
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeExtendedNavTimingFlowRecord(t *testing.T) {
+	rec := ExtendedNavTimingFlow{
+		Type:                       NavTypeReload,
+		RedirectCount:              1,
+		NavigationStart:            1000,
+		RedirectStart:              1001,
+		RedirectEnd:                1020,
+		FetchStart:                 1021,
+		DomainLookupStart:          1022,
+		DomainLookupEnd:            1040,
+		ConnectStart:               1040,
+		ConnectEnd:                 1080,
+		SecureConnectionStart:      1050,
+		RequestStart:               1081,
+		ResponseStart:              1150,
+		ResponseEnd:                1200,
+		DomLoading:                 1160,
+		DomInteractive:             1300,
+		DomContentLoadedEventStart: 1301,
+		DomContentLoadedEventEnd:   1310,
+		DomComplete:                1500,
+		LoadEventStart:             1501,
+		LoadEventEnd:               1520,
+		TypeName:                   "reload",
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+rec.calculateBinarySize() {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+rec.calculateBinarySize(), b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeFlow(b, TypeExtendedNavTimingFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
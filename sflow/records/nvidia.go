@@ -0,0 +1,58 @@
+package records
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// NVIDIAGPUCounter - TypeNVIDIAGPUCounterRecord
+// GPU statistics as exported by the hsflowd NVML module, summed (or maximised) across all devices of the host.
+type NVIDIAGPUCounter struct {
+	DeviceCount uint32 /* see nvmlGetDeviceCount */
+	Processes   uint32 /* see nvmlDeviceGetComputeRunningProcesses */
+	GPUTime     uint32 /* total milliseconds in which one or more kernels was executing on GPU */
+	MemTime     uint32 /* total milliseconds during which global device memory was being read/written */
+	MemTotal    uint64 /* framebuffer memory in bytes */
+	MemFree     uint64 /* free framebuffer memory in bytes */
+	ECCErrors   uint32 /* volatile ECC errors */
+	Energy      uint32 /* millijoules */
+	Temperature uint32 /* maximum temperature in degrees Celsius */
+	FanSpeed    uint32 /* maximum fan speed in percent */
+}
+
+func (c NVIDIAGPUCounter) String() string {
+	type X NVIDIAGPUCounter
+	x := X(c)
+	return fmt.Sprintf("NVIDIAGPUCounter: %+v", x)
+}
+
+// RecordName returns the Name of this counter record
+func (c NVIDIAGPUCounter) RecordName() string {
+	return "NVIDIAGPUCounter"
+}
+
+// RecordType returns the ID of the sflow counter record
+func (c NVIDIAGPUCounter) RecordType() int {
+	return TypeNVIDIAGPUCounterRecord
+}
+
+func (c NVIDIAGPUCounter) calculateBinarySize() int {
+	return binary.Size(c)
+}
+
+func (c NVIDIAGPUCounter) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(c.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, c)
+}
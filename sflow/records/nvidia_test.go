@@ -0,0 +1,43 @@
+package records
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeNVIDIAGPUCounterRecord(t *testing.T) {
+	rec := NVIDIAGPUCounter{
+		DeviceCount: 4,
+		Processes:   7,
+		GPUTime:     59000,
+		MemTime:     12000,
+		MemTotal:    4 * 16 << 30,
+		MemFree:     3 << 30,
+		ECCErrors:   1,
+		Energy:      1200000,
+		Temperature: 71,
+		FanSpeed:    55,
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+48 {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+48, b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeCounter(b, TypeNVIDIAGPUCounterRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
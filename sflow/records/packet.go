@@ -0,0 +1,241 @@
+package records
+
+import (
+	"bytes"
+	"io"
+	"net"
+)
+
+// DecodedPacket is the result of decoding the sampled header of a RawPacketFlow layer by layer.
+// Layers which are not present in the sampled header are nil.
+type DecodedPacket struct {
+	Link        *LinkLayer        `json:"link,omitempty"`
+	VLANs       []VLANTag         `json:"vlans,omitempty"`
+	Network     *NetworkLayer     `json:"network,omitempty"`
+	Transport   *TransportLayer   `json:"transport,omitempty"`
+	Application *ApplicationLayer `json:"application,omitempty"`
+	Inner       *DecodedPacket    `json:"inner,omitempty"`   /* packet carried by a tunnel (IP in IP, GRE) */
+	Layers      map[string]Layer  `json:"layers,omitempty"`  /* layers decoded by registered dissectors, see SetLayer */
+	Unknown     *UnknownProtocol  `json:"unknown,omitempty"` /* protocol at which decoding stopped for lack of a dissector */
+	Errors      []string          `json:"errors,omitempty"`
+	Offset      int               `json:"offset"` /* number of bytes of the sampled header consumed by the decoders */
+
+	embedded bool   /* original packet quoted by an ICMP error message */
+	header   []byte /* sampled header the packet is decoded from, see unread */
+	storage  packetStorage
+}
+
+// packetStorage holds the layers and headers most sampled packets consist of. The layers of a DecodedPacket
+// point into its storage, so decoding these headers does not allocate. Together with the addresses which
+// refer to the sampled header this lets DecodeFlowBytes reuse a DecodedPacket without allocating.
+type packetStorage struct {
+	reader      bytes.Reader
+	link        LinkLayer
+	network     NetworkLayer
+	transport   TransportLayer
+	application ApplicationLayer
+	ethernet    EthernetHeader
+	vlans       [2]VLANTag
+	ipv4        IPv4Header
+	ipv6        IPv6Header
+	tcp         TCPHeader
+	udp         UDPHeader
+	optionNames [4]string                  /* TCPHeader.OptionNames */
+	truncated   [TCPMinimumHeaderSize]byte /* TCP or UDP header cut short by the sampling */
+}
+
+// Layers of UnknownProtocol
+const (
+	UnknownHeaderProtocol = "headerProtocol" /* RawPacketFlow.Protocol */
+	UnknownEtherType      = "etherType"
+	UnknownIPProtocol     = "ipProtocol"
+)
+
+// UnknownProtocol is a protocol found in a sampled header which has no dissector
+type UnknownProtocol struct {
+	Layer    string `json:"layer"`
+	Protocol uint32 `json:"protocol"`
+}
+
+// LinkLayer holds the link layer headers of a DecodedPacket
+type LinkLayer struct {
+	Ethernet   *EthernetHeader   `json:"ethernet,omitempty"`
+	TokenRing  *TokenRingHeader  `json:"tokenRing,omitempty"`
+	FDDI       *FDDIHeader       `json:"fddi,omitempty"`
+	PPP        *PPPHeader        `json:"ppp,omitempty"`
+	FrameRelay *FrameRelayHeader `json:"frameRelay,omitempty"`
+	LLC        *LLCHeader        `json:"llc,omitempty"`
+	SNAP       *SNAPHeader       `json:"snap,omitempty"`
+}
+
+// NetworkLayer holds the network layer headers of a DecodedPacket
+type NetworkLayer struct {
+	IPv4 *IPv4Header `json:"ipv4,omitempty"`
+	IPv6 *IPv6Header `json:"ipv6,omitempty"`
+	ARP  *ARPHeader  `json:"arp,omitempty"`
+	IPX  *IPXHeader  `json:"ipx,omitempty"`
+	LLDP *LLDPHeader `json:"lldp,omitempty"`
+}
+
+// TransportLayer holds the transport layer headers of a DecodedPacket
+type TransportLayer struct {
+	TCP    *TCPHeader  `json:"tcp,omitempty"`
+	UDP    *UDPHeader  `json:"udp,omitempty"`
+	ICMP   *ICMPHeader `json:"icmp,omitempty"`
+	ICMPv6 *ICMPHeader `json:"icmpv6,omitempty"`
+	GRE    *GREHeader  `json:"gre,omitempty"`
+}
+
+// ApplicationLayer holds the application layer data of a DecodedPacket
+type ApplicationLayer struct {
+	DNS  *DNSHeader         `json:"dns,omitempty"`
+	TLS  *TLSClientHello    `json:"tls,omitempty"`
+	HTTP *HTTPRequestHeader `json:"http,omitempty"`
+}
+
+func (p *DecodedPacket) link() *LinkLayer {
+	if p.Link == nil {
+		p.Link = &p.storage.link
+	}
+	return p.Link
+}
+
+func (p *DecodedPacket) network() *NetworkLayer {
+	if p.Network == nil {
+		p.Network = &p.storage.network
+	}
+	return p.Network
+}
+
+func (p *DecodedPacket) transport() *TransportLayer {
+	if p.Transport == nil {
+		p.Transport = &p.storage.transport
+	}
+	return p.Transport
+}
+
+func (p *DecodedPacket) application() *ApplicationLayer {
+	if p.Application == nil {
+		p.Application = &p.storage.application
+	}
+	return p.Application
+}
+
+// reset clears p for decoding the given sampled header
+func (p *DecodedPacket) reset(header []byte) {
+	*p = DecodedPacket{header: header}
+}
+
+// encapsulated returns a new packet for the packet carried by p, which is decoded from the same sampled header
+func (p *DecodedPacket) encapsulated() *DecodedPacket {
+	return &DecodedPacket{embedded: p.embedded, header: p.header}
+}
+
+// unread returns the bytes of the sampled header h has not read yet, so they can be decoded in place.
+// It returns nil if p does not know the sampled header.
+func (p *DecodedPacket) unread(h *bytes.Reader) []byte {
+	if p.header == nil || h.Len() > len(p.header) {
+		return nil
+	}
+	return p.header[len(p.header)-h.Len():]
+}
+
+// next returns up to n of the unread bytes of the sampled header and advances h past them. They refer to
+// the sampled header, or are copied from h if p does not know it.
+func (p *DecodedPacket) next(h *bytes.Reader, n int) []byte {
+	b := p.unread(h)
+	if b == nil {
+		b = make([]byte, n)
+		n, _ = io.ReadFull(h, b)
+		return b[:n]
+	}
+
+	if n > len(b) {
+		n = len(b)
+	}
+	h.Seek(int64(n), io.SeekCurrent)
+	return b[:n:n]
+}
+
+// headerReader returns a reader decoding the unread bytes of the sampled header in place, or reading them
+// from h if p does not know the sampled header. skip advances h past the bytes decoded in place.
+func (p *DecodedPacket) headerReader(h *bytes.Reader) xdrReader {
+	if b := p.unread(h); b != nil {
+		return xdrReader{b: b}
+	}
+	return xdrReader{r: h}
+}
+
+// truncatedReader returns a reader decoding a fixed size header of up to len(packetStorage.truncated) bytes
+// like decodeTruncated. Missing bytes are left zero and io.ErrUnexpectedEOF is returned, or io.EOF if h is at its end.
+func (p *DecodedPacket) truncatedReader(h *bytes.Reader, size int) (xdrReader, error) {
+	b := p.storage.truncated[:size]
+
+	n, _ := h.Read(b)
+	if n == 0 {
+		return xdrReader{}, io.EOF
+	}
+
+	for i := n; i < size; i++ {
+		b[i] = 0
+	}
+
+	if n < size {
+		return xdrReader{b: b}, io.ErrUnexpectedEOF
+	}
+	return xdrReader{b: b}, nil
+}
+
+// Layer is a header or payload decoded by a registered dissector which has no field in DecodedPacket.
+// DecodedPacket.Layers holds these by their LayerName and is the only part of the model whose types
+// are not known to this package, their JSON representation is up to the dissector.
+type Layer interface {
+	LayerName() string
+}
+
+// SetLayer stores a layer decoded by a registered dissector which has no field in DecodedPacket
+func (p *DecodedPacket) SetLayer(layer Layer) {
+	if p.Layers == nil {
+		p.Layers = map[string]Layer{}
+	}
+	p.Layers[layer.LayerName()] = layer
+}
+
+// SrcIP returns the source address of the IPv4 or IPv6 header or nil if there is none
+func (p *DecodedPacket) SrcIP() net.IP {
+	switch {
+	case p.Network == nil:
+		return nil
+	case p.Network.IPv4 != nil:
+		return p.Network.IPv4.SrcAddr
+	case p.Network.IPv6 != nil:
+		return p.Network.IPv6.SrcAddr
+	}
+	return nil
+}
+
+// DstIP returns the destination address of the IPv4 or IPv6 header or nil if there is none
+func (p *DecodedPacket) DstIP() net.IP {
+	switch {
+	case p.Network == nil:
+		return nil
+	case p.Network.IPv4 != nil:
+		return p.Network.IPv4.DstAddr
+	case p.Network.IPv6 != nil:
+		return p.Network.IPv6.DstAddr
+	}
+	return nil
+}
+
+// Ports returns the source and destination port of the TCP or UDP header and false if there is none
+func (p *DecodedPacket) Ports() (uint16, uint16, bool) {
+	switch {
+	case p.Transport == nil:
+		return 0, 0, false
+	case p.Transport.TCP != nil:
+		return p.Transport.TCP.SrcPort, p.Transport.TCP.DstPort, true
+	case p.Transport.UDP != nil:
+		return p.Transport.UDP.SrcPort, p.Transport.UDP.DstPort, true
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,124 @@
+package records
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeGRETunnel(t *testing.T) {
+	header := []byte{
+		// IPv4 203.0.113.1 -> 203.0.113.2, GRE
+		0x45, 0x00, 0x00, 0x3c, 0x00, 0x01, 0x00, 0x00, 0x40, 0x2f, 0x00, 0x00,
+		203, 0, 113, 1, 203, 0, 113, 2,
+		// GRE with key 42, IPv4
+		0x20, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x2a,
+	}
+	header = append(header, testIPv4UDPPacket...)
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, header)
+
+	expected := GREHeader{FlagsVersion: GREFlagKey, ProtocolType: HeaderTypeIPv4, Key: 42}
+	if gre := p.transport().GRE; !reflect.DeepEqual(gre, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, gre)
+	}
+
+	if p.Inner == nil {
+		t.Fatalf("expected an inner packet, got %+v", p)
+	}
+	if src := p.Inner.SrcIP(); !src.Equal(net.IPv4(192, 0, 2, 1)) {
+		t.Errorf("expected inner source 192.0.2.1, got %s", src)
+	}
+	if udp := p.Inner.transport().UDP; !reflect.DeepEqual(udp, &testIPv4UDPHeader) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", testIPv4UDPHeader, udp)
+	}
+	if p.Offset != len(header) {
+		t.Errorf("expected offset %d, got %d", len(header), p.Offset)
+	}
+}
+
+func TestDecodeIPInIPTunnel(t *testing.T) {
+	header := []byte{
+		// IPv4 203.0.113.1 -> 203.0.113.2, IP in IP
+		0x45, 0x00, 0x00, 0x30, 0x00, 0x01, 0x00, 0x00, 0x40, 0x04, 0x00, 0x00,
+		203, 0, 113, 1, 203, 0, 113, 2,
+	}
+	header = append(header, testIPv4UDPPacket...)
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, header)
+
+	if src := p.SrcIP(); !src.Equal(net.IPv4(203, 0, 113, 1)) {
+		t.Errorf("expected outer source 203.0.113.1, got %s", src)
+	}
+	if _, _, ok := p.Ports(); ok {
+		t.Errorf("expected no ports on the outer packet")
+	}
+
+	if p.Inner == nil {
+		t.Fatalf("expected an inner packet, got %+v", p)
+	}
+	if src, dst, ok := p.Inner.Ports(); !ok || src != 5000 || dst != 6000 {
+		t.Errorf("unexpected inner ports %d -> %d", src, dst)
+	}
+}
+
+func TestDecodeTruncatedPacket(t *testing.T) {
+	// The sample ends within the UDP header
+	header := testIPv4UDPPacket[:24]
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, header)
+
+	if p.network().IPv4 == nil {
+		t.Fatalf("expected an IPv4 header, got %+v", p)
+	}
+	if len(p.Errors) != 1 {
+		t.Errorf("expected a single decode error, got %v", p.Errors)
+	}
+	if p.Offset != len(header) {
+		t.Errorf("expected offset %d, got %d", len(header), p.Offset)
+	}
+}
+
+// testJSONTags fails for every exported field of the structs reachable from t without a named json tag
+func testJSONTags(t *testing.T, typ reflect.Type, seen map[reflect.Type]bool) {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || seen[typ] {
+		return
+	}
+	seen[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name := strings.Split(field.Tag.Get("json"), ",")[0]; name == "" {
+			t.Errorf("%s.%s: no json name", typ.Name(), field.Name)
+		}
+		testJSONTags(t, field.Type, seen)
+	}
+}
+
+func TestDecodedPacketJSONTags(t *testing.T) {
+	testJSONTags(t, reflect.TypeOf(DecodedPacket{}), map[reflect.Type]bool{})
+}
+
+func TestDecodedPacketJSON(t *testing.T) {
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4UDPPacket)
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, member := range []string{`"network":{"ipv4":{`, `"srcAddr":"192.0.2.1"`, `"transport":{"udp":{"srcPort":5000,"dstPort":6000,`} {
+		if !strings.Contains(string(b), member) {
+			t.Errorf("expected %s in %s", member, b)
+		}
+	}
+}
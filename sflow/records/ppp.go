@@ -0,0 +1,91 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// PPP Protocol Numbers (see https://www.iana.org/assignments/ppp-numbers)
+const (
+	PPPProtocolIPv4   = 0x0021
+	PPPProtocolIPX    = 0x002b
+	PPPProtocolIPv6   = 0x0057
+	PPPProtocolMPLS   = 0x0281
+	PPPProtocolIPCP   = 0x8021
+	PPPProtocolIPV6CP = 0x8057
+	PPPProtocolLCP    = 0xc021
+	PPPProtocolPAP    = 0xc023
+	PPPProtocolLQR    = 0xc025
+	PPPProtocolCHAP   = 0xc223
+)
+
+var pppProtocolNames = map[uint32]string{
+	PPPProtocolIPv4:   "IPv4",
+	PPPProtocolIPX:    "IPX",
+	PPPProtocolIPv6:   "IPv6",
+	PPPProtocolMPLS:   "MPLS",
+	PPPProtocolIPCP:   "IPCP",
+	PPPProtocolIPV6CP: "IPV6CP",
+	PPPProtocolLCP:    "LCP",
+	PPPProtocolPAP:    "PAP",
+	PPPProtocolLQR:    "LQR",
+	PPPProtocolCHAP:   "CHAP",
+}
+
+// PPPHeader as found in RawPacketFlow.Header
+// Address and Control are zero if the sender compressed them away.
+type PPPHeader struct {
+	Address      uint8  `json:"address"`
+	Control      uint8  `json:"control"`
+	Protocol     uint16 `json:"protocol"`
+	ProtocolName string `json:"protocolName"`
+}
+
+// decodePPP decodes a PPP frame in HDLC-like framing (RFC 1662) and its IP or IPX payload
+func decodePPP(h *bytes.Reader, p *DecodedPacket) error {
+	ppp := PPPHeader{}
+
+	b, err := h.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	// Address and Control Field Compression
+	if b == 0xff {
+		ppp.Address = b
+		if ppp.Control, err = h.ReadByte(); err != nil {
+			return err
+		}
+		if b, err = h.ReadByte(); err != nil {
+			return err
+		}
+	}
+
+	// Protocol Field Compression: an odd first byte is a single byte protocol number
+	if b&0x01 == 0x01 {
+		ppp.Protocol = uint16(b)
+	} else {
+		if err = h.UnreadByte(); err != nil {
+			return err
+		}
+		if err = binary.Read(h, binary.BigEndian, &ppp.Protocol); err != nil {
+			return err
+		}
+	}
+
+	ppp.ProtocolName = lookupName(pppProtocolNames, uint32(ppp.Protocol))
+	p.link().PPP = &ppp
+
+	switch ppp.Protocol {
+	case PPPProtocolIPv4:
+		return decodeIPHeader(4, h, p)
+	case PPPProtocolIPv6:
+		return decodeIPHeader(6, h, p)
+	case PPPProtocolIPX:
+		ipx := IPXHeader{}
+		_, err = decodeInto(h, &ipx)
+		p.network().IPX = &ipx
+	}
+
+	return err
+}
@@ -0,0 +1,37 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodePPP(t *testing.T) {
+	header := append([]byte{0xff, 0x03, 0x00, 0x21}, testIPv4UDPPacket...)
+
+	p := decodeTestPacket(t, HeaderProtocolPPP, header)
+
+	expected := PPPHeader{Address: 0xff, Control: 0x03, Protocol: PPPProtocolIPv4, ProtocolName: "IPv4"}
+	if ppp := p.link().PPP; !reflect.DeepEqual(ppp, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, ppp)
+	}
+
+	if udp := p.transport().UDP; !reflect.DeepEqual(udp, &testIPv4UDPHeader) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", testIPv4UDPHeader, udp)
+	}
+}
+
+func TestDecodePPPCompressed(t *testing.T) {
+	// Address, control and protocol field compression
+	header := append([]byte{0x21}, testIPv4UDPPacket...)
+
+	p := decodeTestPacket(t, HeaderProtocolPPP, header)
+
+	expected := PPPHeader{Protocol: PPPProtocolIPv4, ProtocolName: "IPv4"}
+	if ppp := p.link().PPP; !reflect.DeepEqual(ppp, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, ppp)
+	}
+
+	if udp := p.transport().UDP; !reflect.DeepEqual(udp, &testIPv4UDPHeader) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", testIPv4UDPHeader, udp)
+	}
+}
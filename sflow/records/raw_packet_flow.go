@@ -3,11 +3,11 @@ package records
 import (
 	"bytes"
 	"encoding/binary"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net"
+	"reflect"
 )
 
 // Header Protocol Types found in Raw Packet Flow Record
@@ -26,12 +26,19 @@ const (
 	HeaderProtocolIPv6              = 12
 )
 
-// Raw Packet Header Types
+// Raw Packet Header Types (EtherTypes)
 const (
-	HeaderTypeIPv4 = "0800"
-	HeaderTypeIPv6 = "86DD"
-
-//IPX: type_len == 0x0200 || type_len == 0x0201 || type_len == 0x0600
+	HeaderTypeIPv4                        = 0x0800
+	HeaderTypeARP                         = 0x0806
+	HeaderTypeVLAN                        = 0x8100
+	HeaderTypeIPX                         = 0x8137
+	HeaderTypeTransparentEthernetBridging = 0x6558 // Ethernet frames carried by GRE
+	HeaderTypeIPv6                        = 0x86dd
+	HeaderTypeQinQ                        = 0x88a8
+	HeaderTypeLLDP                        = 0x88cc
+
+	// Values up to HeaderTypeMaximumLength are the payload length of an IEEE 802.3 frame followed by an 802.2 LLC header
+	HeaderTypeMaximumLength = 1500
 )
 
 // RawPacketFlow is a raw Ethernet header flow record.
@@ -41,13 +48,13 @@ type RawPacketFlow struct {
 	Stripped      uint32
 	HeaderSize    uint32
 	Header        []byte
-	DecodedHeader map[string]interface{}
+	DecodedHeader *DecodedPacket
 }
 
 // EthernetHeader as found in RawPacketFlow.Header
 type EthernetHeader struct {
-	DstMac HardwareAddr
-	SrcMac HardwareAddr
+	DstMac HardwareAddr `json:"dstMac"`
+	SrcMac HardwareAddr `json:"srcMac"`
 }
 
 // HardwareAddr alias of net.HardwareAddr to be able to add JSON Marhshalling
@@ -66,59 +73,16 @@ func (e *HardwareAddr) UnmarshalJSON(value []byte) error {
 	return err
 }
 
-// IPv4Header as found in RawPacketFlow.Header
-type IPv4Header struct {
-	VersionAndLen uint8
-	Tos           uint8
-	TotLen        uint16
-	ID            uint16
-	FragOff       uint16
-	TTL           uint8
-	Protocol      uint8
-	Check         uint16
-	SrcAddr       net.IP `ipVersion:"4"`
-	DstAddr       net.IP `ipVersion:"4"`
-}
-
-// IPv6Header as found in RawPacketFlow.Header
-type IPv6Header struct {
-	VersionAndPriority uint8
-	Label1             uint8
-	Label2             uint8
-	Label3             uint8
-	PayloadLength      uint16
-	NextHeader         uint8
-	TTL                uint8
-	SrcAddr            net.IP `ipVersion:"6"`
-	DstAddr            net.IP `ipVersion:"6"`
-}
-
-// TCPHeader as found in RawPacketFlow.Header
-type TCPHeader struct {
-	SrcPort  uint16
-	DstPort  uint16
-	Seq      uint32
-	Ack      uint32
-	UnUsed   uint8
-	Flags    uint8
-	Window   uint16
-	Checksum uint16
-	Urgent   uint16
-}
-
 // UDPHeader as found in RawPacketFlow.Header
 type UDPHeader struct {
-	SrcPort  uint16
-	DstPort  uint16
-	Length   uint16
-	Checksum uint16
+	SrcPort  uint16 `json:"srcPort"`
+	DstPort  uint16 `json:"dstPort"`
+	Length   uint16 `json:"length"`
+	Checksum uint16 `json:"checksum"`
 }
 
-// ICMPHeader as found in RawPacketFlow.Header
-type ICMPHeader struct {
-	Type uint8
-	Code uint8
-}
+// udpHeaderSize is the encoded size of a UDPHeader
+const udpHeaderSize = 8
 
 func (f RawPacketFlow) String() string {
 	type X RawPacketFlow
@@ -136,121 +100,254 @@ func (f RawPacketFlow) RecordName() string {
 	return "RawPacketFlow"
 }
 
-func (f *RawPacketFlow) decodeIPHeader(ipVersion int, h io.Reader) error {
+// decodeTruncated decodes a fixed size header like decodeInto, but tolerates headers
+// cut short by the sampling process. Missing fields are left zero and io.ErrUnexpectedEOF is returned.
+func decodeTruncated(h *bytes.Reader, s interface{}) error {
+	buffer := make([]byte, wireSize(s))
+
+	n, _ := io.ReadFull(h, buffer)
+	if n == 0 {
+		return io.EOF
+	}
+
+	if _, err := decodeInto(bytes.NewReader(buffer), s); err != nil {
+		return err
+	}
+
+	if n < len(buffer) {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+// wireSize returns the encoded size of a header struct, leaving out fields marked with "ignoreOnMarshal"
+func wireSize(s interface{}) int {
+	if size := binary.Size(s); size != -1 && !hasIgnoredFields(s) {
+		return size
+	}
+
+	var size int
+
+	data := reflect.Indirect(reflect.ValueOf(s))
+	for i := 0; i < data.NumField(); i++ {
+		if data.Type().Field(i).Tag.Get("ignoreOnMarshal") == "true" {
+			continue
+		}
+		size += binary.Size(data.Field(i).Interface())
+	}
+
+	return size
+}
+
+// decodeIPHeader decodes an IP header and the following layer 4 header from h into p.
+// The layer 4 header is decoded by the dissector registered for its protocol number.
+func decodeIPHeader(ipVersion int, h *bytes.Reader, p *DecodedPacket) error {
 	var err error
+	var protocol uint8
+	var fragment bool
 
 	if ipVersion == 4 {
-		ip := IPv4Header{}
+		ip := &p.storage.ipv4
 
-		_, err = decodeInto(h, &ip)
-		f.DecodedHeader["ip"] = ip
+		err = decodeIPv4Header(h, p, ip)
+		p.network().IPv4 = ip
 
 		if err != nil {
 			return err
 		}
 
-		//Can we decode a following Layer4 Protocol Header?
-		// See https://en.wikipedia.org/wiki/List_of_IP_protocol_numbers
-		switch ip.Protocol {
-		case IPProtocolESP, IPProtocolAH:
-			// No use in decoding ipsec headers
-			break
-		case IPProtocolTCP:
-			tcp := TCPHeader{}
-			_, err = decodeInto(h, &tcp)
-			f.DecodedHeader["tcp"] = tcp
-
-			if err != nil {
-				return err
-			}
-		case IPProtocolUDP:
-			udp := UDPHeader{}
-			_, err = decodeInto(h, &udp)
-			f.DecodedHeader["udp"] = udp
-			if err != nil {
-				return err
-			}
-		case IPProtocolICMP:
-			icmp := ICMPHeader{}
-			_, err = decodeInto(h, &icmp)
-			f.DecodedHeader["icmp"] = icmp
-			if err != nil {
-				return err
-			}
-		default:
-			fmt.Printf("Unknown Protocol: %d\n", ip.Protocol)
-		}
-
+		protocol = ip.Protocol
+		fragment = ip.FragmentOffset != 0
 	} else if ipVersion == 6 {
-		//FIXME: IPv6 has complex Header Extensions
-		//FIXME: IMPLEMENT ME
-		return fmt.Errorf("IPv6 is not implemented yet")
+		ip := &p.storage.ipv6
 
-		/*IPHeader := IPv6Header{}
+		protocol, err = decodeIPv6Header(h, p, ip)
+		p.network().IPv6 = ip
 
-		if err = Decode(h, &IPHeader); err != nil {
+		if err != nil {
 			return err
 		}
-		f.DecodedHeader["ip"] = IPHeader*/
+
+		fragment = ip.FragmentOffset != 0
+	}
+
+	// Only the first fragment of a packet carries the Layer4 Protocol Header
+	if fragment {
+		return nil
+	}
+
+	//Can we decode a following Layer4 Protocol Header?
+	// See https://en.wikipedia.org/wiki/List_of_IP_protocol_numbers
+	d, found := ipProtocolDissectors[protocol]
+	if !found {
+		p.Unknown = &UnknownProtocol{Layer: UnknownIPProtocol, Protocol: uint32(protocol)}
+		return nil
+	}
+
+	return d(h, p)
+}
+
+// dissectUDP decodes a UDP header and its payload from h into p
+func dissectUDP(h *bytes.Reader, p *DecodedPacket) error {
+	udp := &p.storage.udp
+
+	x, err := p.truncatedReader(h, udpHeaderSize)
+	if err == io.EOF {
+		return err
+	}
+	udp.decodeXDR(&x)
+	p.transport().UDP = udp
+
+	if err != nil {
+		return err
+	}
+
+	return decodePayload(IPProtocolUDP, udp.SrcPort, udp.DstPort, h, p)
+}
+
+// decodeIPByVersion decodes an IP header of the version found in its first 4 bits
+func decodeIPByVersion(h *bytes.Reader, p *DecodedPacket) error {
+	b, err := h.ReadByte()
+	if err != nil {
+		return err
+	}
+	if err = h.UnreadByte(); err != nil {
+		return err
+	}
+
+	switch b >> 4 {
+	case 4:
+		return decodeIPHeader(4, h, p)
+	case 6:
+		return decodeIPHeader(6, h, p)
 	}
 
 	return nil
 }
 
+// decodeEtherType decodes the header following an EtherType (or an IEEE 802.3 length) from h into p
+// using the dissector registered for the EtherType
+func decodeEtherType(typeLen uint16, h *bytes.Reader, p *DecodedPacket) error {
+	if typeLen <= HeaderTypeMaximumLength {
+		return decodeLLC(h, p)
+	}
+
+	if d, found := etherTypeDissectors[typeLen]; found {
+		return d(h, p)
+	}
+
+	p.Unknown = &UnknownProtocol{Layer: UnknownEtherType, Protocol: uint32(typeLen)}
+	return nil
+}
+
+// decodeEthernet decodes an Ethernet header and the header following its EtherType from h into p
+func decodeEthernet(h *bytes.Reader, p *DecodedPacket) error {
+	ethernet := &p.storage.ethernet
+	var typeLen uint16
+
+	// The addresses refer to the sampled header
+	x := p.headerReader(h)
+	ethernet.decodeXDR(&x)
+	p.link().Ethernet = ethernet
+
+	// Determine the Type of the next Header
+	x.uint16(&typeLen)
+	if err := x.skip(h); err != nil {
+		return err
+	}
+
+	return decodeEtherType(typeLen, h, p)
+}
+
+// decodeHeader decodes the sampled header into f.DecodedHeader, which is reused if set (see decodeRawPacketFlowBytes)
 func (f *RawPacketFlow) decodeHeader(headerType uint32) error {
 	var err error
 
-	f.DecodedHeader = make(map[string]interface{})
+	p := f.DecodedHeader
+	if p == nil {
+		p = &DecodedPacket{}
+		f.DecodedHeader = p
+	}
+	p.reset(f.Header)
 
 	if len(f.Header) < MinimumEthernetHeaderSize {
 		return nil
 	}
 
-	h := bytes.NewReader(f.Header)
+	h := &p.storage.reader
+	h.Reset(f.Header)
 
 	switch headerType {
 	case HeaderProtocolEthernetISO8023:
-		ethernet := EthernetHeader{}
-		_, err = decodeInto(h, &ethernet)
-		f.DecodedHeader["ethernet"] = ethernet
-		if err != nil {
-			return err
-		}
-
-		// Determine the Type of the next Header
-		buffer := make([]byte, 2)
-		if err = binary.Read(h, binary.BigEndian, &buffer); err != nil {
-			return err
-		}
-
-		//TODO: Handle VSNAP / 802.2/802 &  IPX
-
-		switch hex.EncodeToString(buffer) {
-		case HeaderTypeIPv4:
-			if err = f.decodeIPHeader(4, h); err != nil {
-				return err
-			}
-		case HeaderTypeIPv6:
-			if err = f.decodeIPHeader(6, h); err != nil {
-				return err
-			}
-		}
+		err = decodeEthernet(h, p)
+	case HeaderProtocolISO88024Tokenring:
+		err = decodeTokenRing(h, p)
+	case HeaderProtocolFDDI:
+		err = decodeFDDI(h, p)
+	case HeaderProtocolFrameRelay:
+		err = decodeFrameRelay(h, p)
+	case HeaderProtocolPPP:
+		err = decodePPP(h, p)
+	case HeaderProtocolAAL5:
+		// LLC encapsulated AAL5 PDU (RFC 2684)
+		err = decodeLLC(h, p)
+	case HeaderProtocolAAL5IP:
+		// VC multiplexed routed AAL5 PDU, the IP version is taken from the header
+		err = decodeIPByVersion(h, p)
 	case HeaderProtocolIPv4:
-		if err = f.decodeIPHeader(4, h); err != nil {
-			return err
-		}
+		err = decodeIPHeader(4, h, p)
 	case HeaderProtocolIPv6:
-		if err = f.decodeIPHeader(6, h); err != nil {
-			return err
-		}
+		err = decodeIPHeader(6, h, p)
 	default:
-		fmt.Printf("Unknown Headertype: %d\n", headerType)
+		p.Unknown = &UnknownProtocol{Layer: UnknownHeaderProtocol, Protocol: headerType}
+	}
+
+	// Remember where decoding stopped and why
+	p.Offset = len(f.Header) - h.Len()
+	if err != nil {
+		p.Errors = append(p.Errors, err.Error())
 	}
 
-	//fmt.Printf("Headers: %+#v\n", f.DecodedHeader)
 	return err
 }
 
+// UnmarshalJSON reads a RawPacketFlow from JSON. The DecodedHeader is not read but decoded from the Header.
+// The HeaderSize is set from the Header if it is left out and has to match the Header otherwise.
+func (f *RawPacketFlow) UnmarshalJSON(value []byte) error {
+	type X RawPacketFlow
+	var x struct {
+		X
+		HeaderSize    *uint32
+		DecodedHeader json.RawMessage
+	}
+
+	if err := json.Unmarshal(value, &x); err != nil {
+		return err
+	}
+
+	*f = RawPacketFlow(x.X)
+	f.HeaderSize = uint32(len(f.Header))
+
+	if x.HeaderSize != nil && *x.HeaderSize != f.HeaderSize {
+		return fmt.Errorf("%w: header size %d of a %d byte header", ErrDecodingRecord, *x.HeaderSize, len(f.Header))
+	}
+	if f.HeaderSize > MaximumHeaderLength {
+		return fmt.Errorf("sflow: header length more than %d: %d",
+			MaximumHeaderLength, f.HeaderSize)
+	}
+
+	// The header is taken from the frame after the stripped bytes were removed
+	if uint64(f.Stripped)+uint64(f.HeaderSize) > uint64(f.FrameLength) {
+		return fmt.Errorf("%w: %d byte header and %d stripped bytes of a %d byte frame",
+			ErrDecodingRecord, f.HeaderSize, f.Stripped, f.FrameLength)
+	}
+
+	// As for the binary record, errors decoding the header only end up in DecodedHeader.Errors
+	f.decodeHeader(f.Protocol)
+
+	return nil
+}
+
 // DecodeRawPacketFlow decodes an TypeRawPacketFlowRecord
 func DecodeRawPacketFlow(r io.Reader) (RawPacketFlow, error) {
 	f := RawPacketFlow{}
@@ -297,15 +394,11 @@ func DecodeRawPacketFlow(r io.Reader) (RawPacketFlow, error) {
 	// but len(Header) should still be HeaderSize.
 	f.Header = f.Header[:f.HeaderSize]
 
-	// Try to decode the retrieved headers
-	if err = f.decodeHeader(f.Protocol); err != nil {
-		/*if err = io.ErrUnexpectedEOF {
-		}*/
-		// we don't care so much if it succeeds
-		return f, nil
-	}
+	// Try to decode the retrieved headers. Sampled headers are truncated more often than not, so
+	// errors only end up in DecodedHeader.Errors and do not fail the record.
+	f.decodeHeader(f.Protocol)
 
-	return f, err
+	return f, nil
 }
 
 // Encode create the binary sflow representation of f
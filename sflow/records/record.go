@@ -1,13 +1,22 @@
 package records
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 )
 
 var (
 	ErrEncodingRecord = errors.New("sflow: failed to encode record")
 	ErrDecodingRecord = errors.New("sflow: failed to decode record")
+
+	// ErrUnknownRecordType is wrapped by the errors of DecodeFlow and DecodeCounter for record types without a decoder
+	ErrUnknownRecordType = errors.New("sflow: unknown record type")
+
+	// ErrMaximumLength is wrapped by the errors for variable length fields longer than their maxLength tag,
+	// or than MaximumRecordLength elements if they have none. Such fields are neither allocated nor written.
+	ErrMaximumLength = errors.New("sflow: maximum length exceeded")
 )
 
 type Record interface {
@@ -15,3 +24,32 @@ type Record interface {
 	RecordName() string
 	Encode(w io.Writer) error
 }
+
+// XDRString is a variable length XDR string. It is decoded like opaque data
+// but represented as text in JSON.
+type XDRString []byte
+
+func (s XDRString) String() string {
+	return string(s)
+}
+
+// MarshalJSON creates a JSON string of s
+func (s XDRString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON reads a JSON string into s
+func (s *XDRString) UnmarshalJSON(value []byte) error {
+	var x string
+	err := json.Unmarshal(value, &x)
+	*s = XDRString(x)
+	return err
+}
+
+// lookupName returns the name of an enum value or a placeholder if the value is unknown
+func lookupName(names map[uint32]string, value uint32) string {
+	if name, found := names[value]; found {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", value)
+}
@@ -0,0 +1,183 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// TCP Flags
+const (
+	TCPFlagFIN = 1 << iota
+	TCPFlagSYN
+	TCPFlagRST
+	TCPFlagPSH
+	TCPFlagACK
+	TCPFlagURG
+	TCPFlagECE
+	TCPFlagCWR
+)
+
+var tcpFlagNames = []struct {
+	flag uint8
+	name string
+}{
+	{TCPFlagFIN, "FIN"},
+	{TCPFlagSYN, "SYN"},
+	{TCPFlagRST, "RST"},
+	{TCPFlagPSH, "PSH"},
+	{TCPFlagACK, "ACK"},
+	{TCPFlagURG, "URG"},
+	{TCPFlagECE, "ECE"},
+	{TCPFlagCWR, "CWR"},
+}
+
+// tcpFlagNameSets holds the FlagNames of every combination of TCP flags, so they are not built for every header
+var tcpFlagNameSets = func() (sets [256][]string) {
+	for flags := range sets {
+		for _, f := range tcpFlagNames {
+			if uint8(flags)&f.flag == f.flag {
+				sets[flags] = append(sets[flags], f.name)
+			}
+		}
+		sets[flags] = sets[flags][:len(sets[flags]):len(sets[flags])]
+	}
+	return sets
+}()
+
+// TCP Option Kinds (see https://www.iana.org/assignments/tcp-parameters)
+const (
+	TCPOptionEndOfList     = 0
+	TCPOptionNOP           = 1
+	TCPOptionMSS           = 2
+	TCPOptionWindowScale   = 3
+	TCPOptionSACKPermitted = 4
+	TCPOptionSACK          = 5
+	TCPOptionTimestamps    = 8
+)
+
+var tcpOptionNames = map[uint32]string{
+	TCPOptionMSS:           "MSS",
+	TCPOptionWindowScale:   "WS",
+	TCPOptionSACKPermitted: "SACK_PERM",
+	TCPOptionSACK:          "SACK",
+	TCPOptionTimestamps:    "TS",
+}
+
+// TCPMinimumHeaderSize is the size of a TCP header without options
+const TCPMinimumHeaderSize = 20
+
+// TCPHeader as found in RawPacketFlow.Header
+type TCPHeader struct {
+	SrcPort        uint16 `json:"srcPort"`
+	DstPort        uint16 `json:"dstPort"`
+	Seq            uint32 `json:"seq"`
+	Ack            uint32 `json:"ack"`
+	OffsetReserved uint8  `json:"offsetReserved"` /* data offset (upper 4 bits) and reserved bits */
+	Flags          uint8  `json:"flags"`
+	Window         uint16 `json:"window"`
+	Checksum       uint16 `json:"checksum"`
+	Urgent         uint16 `json:"urgent"`
+
+	DataOffset uint8    `ignoreOnMarshal:"true" json:"dataOffset"` /* header length in 32-bit words */
+	FlagNames  []string `ignoreOnMarshal:"true" json:"flagNames"`
+
+	// Options present in the sampled header
+	OptionNames []string `ignoreOnMarshal:"true" json:"optionNames,omitempty"`
+	MSS         uint16   `ignoreOnMarshal:"true" json:"mss,omitempty"`
+	WindowScale uint8    `ignoreOnMarshal:"true" json:"windowScale,omitempty"`
+	TSVal       uint32   `ignoreOnMarshal:"true" json:"tsVal,omitempty"`
+	TSEcr       uint32   `ignoreOnMarshal:"true" json:"tsEcr,omitempty"`
+}
+
+// HasFlag returns true if all of the given TCP flags are set
+func (tcp TCPHeader) HasFlag(flags uint8) bool {
+	return tcp.Flags&flags == flags
+}
+
+// decodeTCPHeader decodes a TCP header and the options contained in the sampled part of it from h into tcp
+func decodeTCPHeader(h *bytes.Reader, p *DecodedPacket, tcp *TCPHeader) error {
+	*tcp = TCPHeader{}
+
+	x, err := p.truncatedReader(h, TCPMinimumHeaderSize)
+	if err == io.EOF {
+		return err
+	}
+	tcp.decodeXDR(&x)
+	if err != nil {
+		return err
+	}
+
+	tcp.DataOffset = tcp.OffsetReserved >> 4
+	tcp.FlagNames = tcpFlagNameSets[tcp.Flags]
+
+	optionsLength := int(tcp.DataOffset)*4 - TCPMinimumHeaderSize
+	if optionsLength <= 0 {
+		return nil
+	}
+
+	// The sampled header may end within the options
+	options := p.next(h, optionsLength)
+
+	// The names of the first options are held by the storage of p
+	tcp.OptionNames = p.storage.optionNames[:0]
+	tcp.decodeOptions(options)
+	if len(tcp.OptionNames) == 0 {
+		tcp.OptionNames = nil
+	}
+
+	if len(options) < optionsLength {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+// decodeOptions decodes the known options in b, stopping at the end of the option list or at a truncated option
+func (tcp *TCPHeader) decodeOptions(b []byte) {
+	for len(b) > 0 {
+		kind := b[0]
+
+		switch kind {
+		case TCPOptionEndOfList:
+			return
+		case TCPOptionNOP:
+			b = b[1:]
+			continue
+		}
+
+		if len(b) < 2 || b[1] < 2 || int(b[1]) > len(b) {
+			return
+		}
+		value := b[2:b[1]]
+		b = b[b[1]:]
+
+		tcp.OptionNames = append(tcp.OptionNames, lookupName(tcpOptionNames, uint32(kind)))
+
+		switch {
+		case kind == TCPOptionMSS && len(value) == 2:
+			tcp.MSS = binary.BigEndian.Uint16(value)
+		case kind == TCPOptionWindowScale && len(value) == 1:
+			tcp.WindowScale = value[0]
+		case kind == TCPOptionTimestamps && len(value) == 8:
+			tcp.TSVal = binary.BigEndian.Uint32(value[0:4])
+			tcp.TSEcr = binary.BigEndian.Uint32(value[4:8])
+		}
+	}
+}
+
+// dissectTCP decodes a TCP header and its payload from h into p
+func dissectTCP(h *bytes.Reader, p *DecodedPacket) error {
+	tcp := &p.storage.tcp
+
+	err := decodeTCPHeader(h, p, tcp)
+	if err == io.EOF {
+		return err
+	}
+	p.transport().TCP = tcp
+
+	if err != nil {
+		return err
+	}
+
+	return decodePayload(IPProtocolTCP, tcp.SrcPort, tcp.DstPort, h, p)
+}
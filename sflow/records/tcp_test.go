@@ -0,0 +1,69 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeTCPSynOptions(t *testing.T) {
+	header := []byte{
+		// IPv4 192.0.2.1 -> 198.51.100.7, TCP
+		0x45, 0x00, 0x00, 0x3c, 0x00, 0x01, 0x40, 0x00, 0x40, 0x06, 0x00, 0x00,
+		192, 0, 2, 1, 198, 51, 100, 7,
+		// TCP 50000 -> 443, SYN, data offset 10
+		0xc3, 0x50, 0x01, 0xbb, 0x00, 0x00, 0x00, 0x64, 0x00, 0x00, 0x00, 0x00,
+		0xa0, 0x02, 0xfa, 0xf0, 0x12, 0x34, 0x00, 0x00,
+		// MSS 1460, SACK permitted, timestamps, NOP, window scale 7
+		0x02, 0x04, 0x05, 0xb4,
+		0x04, 0x02,
+		0x08, 0x0a, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+		0x01,
+		0x03, 0x03, 0x07,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, header)
+
+	expected := TCPHeader{
+		SrcPort:        50000,
+		DstPort:        443,
+		Seq:            100,
+		OffsetReserved: 0xa0,
+		Flags:          TCPFlagSYN,
+		Window:         64240,
+		Checksum:       0x1234,
+		DataOffset:     10,
+		FlagNames:      []string{"SYN"},
+		OptionNames:    []string{"MSS", "SACK_PERM", "TS", "WS"},
+		MSS:            1460,
+		WindowScale:    7,
+		TSVal:          1,
+	}
+
+	if tcp := p.transport().TCP; !reflect.DeepEqual(tcp, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, tcp)
+	}
+}
+
+func TestDecodeTCPTruncatedOptions(t *testing.T) {
+	header := []byte{
+		// IPv4 192.0.2.1 -> 198.51.100.7, TCP
+		0x45, 0x00, 0x00, 0x3c, 0x00, 0x01, 0x40, 0x00, 0x40, 0x06, 0x00, 0x00,
+		192, 0, 2, 1, 198, 51, 100, 7,
+		// TCP 443 -> 50000, SYN+ACK, data offset 10
+		0x01, 0xbb, 0xc3, 0x50, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x65,
+		0xa0, 0x12, 0xfa, 0xf0, 0x00, 0x00, 0x00, 0x00,
+		// MSS 1400, then the sample ends within the timestamps option
+		0x02, 0x04, 0x05, 0x78,
+		0x08, 0x0a, 0x00, 0x00,
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, header)
+
+	tcp := *p.transport().TCP
+	if !tcp.HasFlag(TCPFlagSYN|TCPFlagACK) || !reflect.DeepEqual(tcp.FlagNames, []string{"SYN", "ACK"}) {
+		t.Errorf("unexpected flags %v", tcp.FlagNames)
+	}
+	if tcp.MSS != 1400 || !reflect.DeepEqual(tcp.OptionNames, []string{"MSS"}) {
+		t.Errorf("unexpected options %v (MSS %d)", tcp.OptionNames, tcp.MSS)
+	}
+}
@@ -0,0 +1,155 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// TLSPort is the well-known port of HTTPS
+const TLSPort = 443
+
+// TLS Record and Handshake Types
+const (
+	TLSRecordTypeHandshake      = 22
+	TLSHandshakeTypeClientHello = 1
+)
+
+// TLS Extension Types
+const (
+	TLSExtensionServerName = 0
+	TLSExtensionALPN       = 16
+)
+
+var tlsVersionNames = map[uint32]string{
+	0x0300: "SSLv3",
+	0x0301: "TLSv1.0",
+	0x0302: "TLSv1.1",
+	0x0303: "TLSv1.2",
+	0x0304: "TLSv1.3",
+}
+
+// TLSClientHello holds the fields of a TLS ClientHello message as found in RawPacketFlow.Header
+// Extensions beyond the end of the sampled header are missing.
+type TLSClientHello struct {
+	Version     uint16   `json:"version"`
+	VersionName string   `json:"versionName"`
+	ServerName  string   `json:"serverName,omitempty"`
+	ALPN        []string `json:"alpn,omitempty"`
+}
+
+// tlsReader reads the length prefixed fields of a TLS handshake message
+type tlsReader struct {
+	b []byte
+}
+
+func (r *tlsReader) skip(n int) bool {
+	if n > len(r.b) {
+		r.b = nil
+		return false
+	}
+	r.b = r.b[n:]
+	return true
+}
+
+// vector returns the content of a vector with a length prefix of n bytes
+func (r *tlsReader) vector(n int) ([]byte, bool) {
+	if len(r.b) < n {
+		r.b = nil
+		return nil, false
+	}
+
+	var length int
+	for _, b := range r.b[:n] {
+		length = length<<8 | int(b)
+	}
+	r.b = r.b[n:]
+
+	if length > len(r.b) {
+		// Return the truncated content
+		v := r.b
+		r.b = nil
+		return v, false
+	}
+
+	v := r.b[:length]
+	r.b = r.b[length:]
+	return v, true
+}
+
+// decodeTLSClientHello decodes the ClientHello in a TLS record. It returns false if b does not start with a ClientHello.
+func decodeTLSClientHello(b []byte) (TLSClientHello, bool) {
+	hello := TLSClientHello{}
+
+	// Record type, version and length followed by handshake type and length
+	if len(b) < 9 || b[0] != TLSRecordTypeHandshake || b[1] != 0x03 || b[5] != TLSHandshakeTypeClientHello {
+		return hello, false
+	}
+
+	r := &tlsReader{b: b[9:]}
+
+	if len(r.b) < 2 {
+		return hello, true
+	}
+	hello.Version = binary.BigEndian.Uint16(r.b)
+	hello.VersionName = lookupName(tlsVersionNames, uint32(hello.Version))
+
+	// Version, random, session id, cipher suites and compression methods
+	if !r.skip(2 + 32) {
+		return hello, true
+	}
+	if _, ok := r.vector(1); !ok {
+		return hello, true
+	}
+	if _, ok := r.vector(2); !ok {
+		return hello, true
+	}
+	if _, ok := r.vector(1); !ok {
+		return hello, true
+	}
+
+	extensions, _ := r.vector(2)
+	r = &tlsReader{b: extensions}
+
+	for len(r.b) >= 4 {
+		extType := binary.BigEndian.Uint16(r.b)
+		r.skip(2)
+
+		data, complete := r.vector(2)
+
+		switch extType {
+		case TLSExtensionServerName:
+			// Server name list, of which the first entry is a host name
+			if len(data) > 5 && data[2] == 0 {
+				names := &tlsReader{b: data[3:]}
+				if name, ok := names.vector(2); ok {
+					hello.ServerName = string(name)
+				}
+			}
+		case TLSExtensionALPN:
+			protocols := &tlsReader{b: data}
+			list, _ := protocols.vector(2)
+			protocols = &tlsReader{b: list}
+			for len(protocols.b) > 0 {
+				protocol, ok := protocols.vector(1)
+				if !ok {
+					break
+				}
+				hello.ALPN = append(hello.ALPN, string(protocol))
+			}
+		}
+
+		if !complete {
+			break
+		}
+	}
+
+	return hello, true
+}
+
+// dissectTLSClientHello decodes a TLS ClientHello from h into p
+func dissectTLSClientHello(h *bytes.Reader, p *DecodedPacket) error {
+	if tls, ok := decodeTLSClientHello(readPayload(h)); ok {
+		p.application().TLS = &tls
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeTLSClientHello(t *testing.T) {
+	testApplicationLayer(t)
+
+	payload := []byte{
+		// TCP 50000 -> 443, PSH+ACK
+		0xc3, 0x50, 0x01, 0xbb, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+		0x50, 0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00,
+		// Handshake record, ClientHello
+		0x16, 0x03, 0x01, 0x00, 0x60, 0x01, 0x00, 0x00, 0x5c,
+		// TLS 1.2 and random
+		0x03, 0x03,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+		// Empty session id, two cipher suites, null compression
+		0x00,
+		0x00, 0x04, 0x13, 0x01, 0xc0, 0x2f,
+		0x01, 0x00,
+		// Extensions
+		0x00, 0x25,
+		// server_name www.example.com
+		0x00, 0x00, 0x00, 0x14, 0x00, 0x12, 0x00, 0x00, 0x0f,
+		'w', 'w', 'w', '.', 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm',
+		// ALPN h2, http/1.1, truncated by the end of the sample
+		0x00, 0x10, 0x00, 0x0e, 0x00, 0x0c, 0x02, 'h', '2', 0x08, 'h', 't', 't',
+	}
+
+	p := decodeTestPacket(t, HeaderProtocolIPv4, testIPv4Packet(IPProtocolTCP, payload))
+
+	expected := TLSClientHello{
+		Version:     0x0303,
+		VersionName: "TLSv1.2",
+		ServerName:  "www.example.com",
+		ALPN:        []string{"h2"},
+	}
+
+	if tls := p.application().TLS; !reflect.DeepEqual(tls, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, tls)
+	}
+}
@@ -0,0 +1,53 @@
+package records
+
+import (
+	"bytes"
+	"io"
+)
+
+// TokenRingHeader as found in RawPacketFlow.Header
+type TokenRingHeader struct {
+	AccessControl            uint8        `json:"accessControl"`
+	FrameControl             uint8        `json:"frameControl"`
+	DstMac                   HardwareAddr `json:"dstMac"`
+	SrcMac                   HardwareAddr `json:"srcMac"`
+	RoutingInformationLength uint8        `ignoreOnMarshal:"true" json:"routingInformationLength,omitempty"`
+}
+
+// IsLLC returns true for LLC frames, as opposed to MAC frames managing the ring
+func (tr TokenRingHeader) IsLLC() bool {
+	return tr.FrameControl&0xc0 == 0x40
+}
+
+// decodeTokenRing decodes a Token Ring frame and its LLC payload
+func decodeTokenRing(h *bytes.Reader, p *DecodedPacket) error {
+	tr := TokenRingHeader{}
+	p.link().TokenRing = &tr
+
+	if _, err := decodeInto(h, &tr); err != nil {
+		return err
+	}
+
+	// Source routed frames set the group bit of the source address and carry a routing information field
+	if len(tr.SrcMac) > 0 && tr.SrcMac[0]&0x80 != 0 {
+		rif, err := h.ReadByte()
+		if err != nil {
+			return err
+		}
+		tr.RoutingInformationLength = rif & 0x1f
+
+		if tr.RoutingInformationLength < 2 {
+			return ErrDecodingRecord
+		}
+
+		if _, err = h.Seek(int64(tr.RoutingInformationLength)-1, io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+
+	if !tr.IsLLC() {
+		return nil
+	}
+
+	return decodeLLC(h, p)
+}
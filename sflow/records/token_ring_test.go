@@ -0,0 +1,36 @@
+package records
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeTokenRing(t *testing.T) {
+	header := []byte{
+		// Access control, LLC frame
+		0x10, 0x40,
+		0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x80, 0x77, 0x88, 0x99, 0xaa, 0xbb,
+		// Routing information field with one route descriptor
+		0x04, 0x30, 0x00, 0x10,
+		// LLC, SNAP, IPv4
+		0xaa, 0xaa, 0x03, 0x00, 0x00, 0x00, 0x08, 0x00,
+	}
+	header = append(header, testIPv4UDPPacket...)
+
+	p := decodeTestPacket(t, HeaderProtocolISO88024Tokenring, header)
+
+	expected := TokenRingHeader{
+		AccessControl:            0x10,
+		FrameControl:             0x40,
+		DstMac:                   HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+		SrcMac:                   HardwareAddr{0x80, 0x77, 0x88, 0x99, 0xaa, 0xbb},
+		RoutingInformationLength: 4,
+	}
+	if tr := p.link().TokenRing; !reflect.DeepEqual(tr, &expected) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", expected, tr)
+	}
+
+	if udp := p.transport().UDP; !reflect.DeepEqual(udp, &testIPv4UDPHeader) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", testIPv4UDPHeader, udp)
+	}
+}
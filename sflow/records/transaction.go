@@ -0,0 +1,425 @@
+package records
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Transaction Directions
+const (
+	TransactionDirectionClient = 1
+	TransactionDirectionServer = 2
+)
+
+var transactionDirectionNames = map[uint32]string{
+	TransactionDirectionClient: "client",
+	TransactionDirectionServer: "server",
+}
+
+// Transaction Status Values
+const (
+	TransactionStatusSucceeded      = 0
+	TransactionStatusGenericFailure = 1
+	TransactionStatusOutOfMemory    = 2
+	TransactionStatusTimeout        = 3
+	TransactionStatusNotPermitted   = 4
+)
+
+var transactionStatusNames = map[uint32]string{
+	TransactionStatusSucceeded:      "succeeded",
+	TransactionStatusGenericFailure: "generic_failure",
+	TransactionStatusOutOfMemory:    "outofmemory",
+	TransactionStatusTimeout:        "timeout",
+	TransactionStatusNotPermitted:   "notpermitted",
+}
+
+// NFS Operations (NFSv3 procedure numbers)
+const (
+	NFSOperationNull        = 0
+	NFSOperationGetattr     = 1
+	NFSOperationSetattr     = 2
+	NFSOperationLookup      = 3
+	NFSOperationAccess      = 4
+	NFSOperationReadlink    = 5
+	NFSOperationRead        = 6
+	NFSOperationWrite       = 7
+	NFSOperationCreate      = 8
+	NFSOperationMkdir       = 9
+	NFSOperationSymlink     = 10
+	NFSOperationMknod       = 11
+	NFSOperationRemove      = 12
+	NFSOperationRmdir       = 13
+	NFSOperationRename      = 14
+	NFSOperationLink        = 15
+	NFSOperationReaddir     = 16
+	NFSOperationReaddirplus = 17
+	NFSOperationFsstat      = 18
+	NFSOperationFsinfo      = 19
+	NFSOperationPathconf    = 20
+	NFSOperationCommit      = 21
+)
+
+var nfsOperationNames = map[uint32]string{
+	NFSOperationNull:        "null",
+	NFSOperationGetattr:     "getattr",
+	NFSOperationSetattr:     "setattr",
+	NFSOperationLookup:      "lookup",
+	NFSOperationAccess:      "access",
+	NFSOperationReadlink:    "readlink",
+	NFSOperationRead:        "read",
+	NFSOperationWrite:       "write",
+	NFSOperationCreate:      "create",
+	NFSOperationMkdir:       "mkdir",
+	NFSOperationSymlink:     "symlink",
+	NFSOperationMknod:       "mknod",
+	NFSOperationRemove:      "remove",
+	NFSOperationRmdir:       "rmdir",
+	NFSOperationRename:      "rename",
+	NFSOperationLink:        "link",
+	NFSOperationReaddir:     "readdir",
+	NFSOperationReaddirplus: "readdirplus",
+	NFSOperationFsstat:      "fsstat",
+	NFSOperationFsinfo:      "fsinfo",
+	NFSOperationPathconf:    "pathconf",
+	NFSOperationCommit:      "commit",
+}
+
+// NFS Status Values (nfsstat3)
+const (
+	NFSStatusOK          = 0
+	NFSStatusPerm        = 1
+	NFSStatusNoEnt       = 2
+	NFSStatusIO          = 5
+	NFSStatusNXIO        = 6
+	NFSStatusAccess      = 13
+	NFSStatusExist       = 17
+	NFSStatusXDev        = 18
+	NFSStatusNoDev       = 19
+	NFSStatusNotDir      = 20
+	NFSStatusIsDir       = 21
+	NFSStatusInval       = 22
+	NFSStatusFBig        = 27
+	NFSStatusNoSpc       = 28
+	NFSStatusROFS        = 30
+	NFSStatusMLink       = 31
+	NFSStatusNameTooLong = 63
+	NFSStatusNotEmpty    = 66
+	NFSStatusDQuot       = 69
+	NFSStatusStale       = 70
+	NFSStatusRemote      = 71
+	NFSStatusBadHandle   = 10001
+	NFSStatusNotSync     = 10002
+	NFSStatusBadCookie   = 10003
+	NFSStatusNotSupp     = 10004
+	NFSStatusTooSmall    = 10005
+	NFSStatusServerFault = 10006
+	NFSStatusBadType     = 10007
+	NFSStatusJukebox     = 10008
+)
+
+var nfsStatusNames = map[uint32]string{
+	NFSStatusOK:          "NFS3_OK",
+	NFSStatusPerm:        "NFS3ERR_PERM",
+	NFSStatusNoEnt:       "NFS3ERR_NOENT",
+	NFSStatusIO:          "NFS3ERR_IO",
+	NFSStatusNXIO:        "NFS3ERR_NXIO",
+	NFSStatusAccess:      "NFS3ERR_ACCES",
+	NFSStatusExist:       "NFS3ERR_EXIST",
+	NFSStatusXDev:        "NFS3ERR_XDEV",
+	NFSStatusNoDev:       "NFS3ERR_NODEV",
+	NFSStatusNotDir:      "NFS3ERR_NOTDIR",
+	NFSStatusIsDir:       "NFS3ERR_ISDIR",
+	NFSStatusInval:       "NFS3ERR_INVAL",
+	NFSStatusFBig:        "NFS3ERR_FBIG",
+	NFSStatusNoSpc:       "NFS3ERR_NOSPC",
+	NFSStatusROFS:        "NFS3ERR_ROFS",
+	NFSStatusMLink:       "NFS3ERR_MLINK",
+	NFSStatusNameTooLong: "NFS3ERR_NAMETOOLONG",
+	NFSStatusNotEmpty:    "NFS3ERR_NOTEMPTY",
+	NFSStatusDQuot:       "NFS3ERR_DQUOT",
+	NFSStatusStale:       "NFS3ERR_STALE",
+	NFSStatusRemote:      "NFS3ERR_REMOTE",
+	NFSStatusBadHandle:   "NFS3ERR_BADHANDLE",
+	NFSStatusNotSync:     "NFS3ERR_NOT_SYNC",
+	NFSStatusBadCookie:   "NFS3ERR_BAD_COOKIE",
+	NFSStatusNotSupp:     "NFS3ERR_NOTSUPP",
+	NFSStatusTooSmall:    "NFS3ERR_TOOSMALL",
+	NFSStatusServerFault: "NFS3ERR_SERVERFAULT",
+	NFSStatusBadType:     "NFS3ERR_BADTYPE",
+	NFSStatusJukebox:     "NFS3ERR_JUKEBOX",
+}
+
+// SCSI Operations (command operation codes)
+const (
+	SCSIOperationTestUnitReady    = 0x00
+	SCSIOperationRequestSense     = 0x03
+	SCSIOperationRead6            = 0x08
+	SCSIOperationWrite6           = 0x0a
+	SCSIOperationInquiry          = 0x12
+	SCSIOperationModeSelect6      = 0x15
+	SCSIOperationModeSense6       = 0x1a
+	SCSIOperationStartStopUnit    = 0x1b
+	SCSIOperationReadCapacity10   = 0x25
+	SCSIOperationRead10           = 0x28
+	SCSIOperationWrite10          = 0x2a
+	SCSIOperationVerify10         = 0x2f
+	SCSIOperationSynchronizeCache = 0x35
+	SCSIOperationWriteSame10      = 0x41
+	SCSIOperationUnmap            = 0x42
+	SCSIOperationModeSelect10     = 0x55
+	SCSIOperationModeSense10      = 0x5a
+	SCSIOperationRead16           = 0x88
+	SCSIOperationWrite16          = 0x8a
+	SCSIOperationVerify16         = 0x8f
+	SCSIOperationWriteSame16      = 0x93
+	SCSIOperationReadCapacity16   = 0x9e
+	SCSIOperationReportLuns       = 0xa0
+	SCSIOperationRead12           = 0xa8
+	SCSIOperationWrite12          = 0xaa
+
+	// SCSIOperationUnknown is used by agents to encode an unknown operation
+	SCSIOperationUnknown = 0xffffffff
+)
+
+var scsiOperationNames = map[uint32]string{
+	SCSIOperationTestUnitReady:    "TEST_UNIT_READY",
+	SCSIOperationRequestSense:     "REQUEST_SENSE",
+	SCSIOperationRead6:            "READ_6",
+	SCSIOperationWrite6:           "WRITE_6",
+	SCSIOperationInquiry:          "INQUIRY",
+	SCSIOperationModeSelect6:      "MODE_SELECT_6",
+	SCSIOperationModeSense6:       "MODE_SENSE_6",
+	SCSIOperationStartStopUnit:    "START_STOP_UNIT",
+	SCSIOperationReadCapacity10:   "READ_CAPACITY_10",
+	SCSIOperationRead10:           "READ_10",
+	SCSIOperationWrite10:          "WRITE_10",
+	SCSIOperationVerify10:         "VERIFY_10",
+	SCSIOperationSynchronizeCache: "SYNCHRONIZE_CACHE",
+	SCSIOperationWriteSame10:      "WRITE_SAME_10",
+	SCSIOperationUnmap:            "UNMAP",
+	SCSIOperationModeSelect10:     "MODE_SELECT_10",
+	SCSIOperationModeSense10:      "MODE_SENSE_10",
+	SCSIOperationRead16:           "READ_16",
+	SCSIOperationWrite16:          "WRITE_16",
+	SCSIOperationVerify16:         "VERIFY_16",
+	SCSIOperationWriteSame16:      "WRITE_SAME_16",
+	SCSIOperationReadCapacity16:   "READ_CAPACITY_16",
+	SCSIOperationReportLuns:       "REPORT_LUNS",
+	SCSIOperationRead12:           "READ_12",
+	SCSIOperationWrite12:          "WRITE_12",
+	SCSIOperationUnknown:          "UNKNOWN",
+}
+
+// SCSI Status Codes
+const (
+	SCSIStatusGood                     = 0x00
+	SCSIStatusCheckCondition           = 0x02
+	SCSIStatusConditionMet             = 0x04
+	SCSIStatusBusy                     = 0x08
+	SCSIStatusIntermediate             = 0x10
+	SCSIStatusIntermediateConditionMet = 0x14
+	SCSIStatusReservationConflict      = 0x18
+	SCSIStatusCommandTerminated        = 0x22
+	SCSIStatusTaskSetFull              = 0x28
+	SCSIStatusACAActive                = 0x30
+	SCSIStatusTaskAborted              = 0x40
+)
+
+var scsiStatusNames = map[uint32]string{
+	SCSIStatusGood:                     "GOOD",
+	SCSIStatusCheckCondition:           "CHECK_CONDITION",
+	SCSIStatusConditionMet:             "CONDITION_MET",
+	SCSIStatusBusy:                     "BUSY",
+	SCSIStatusIntermediate:             "INTERMEDIATE",
+	SCSIStatusIntermediateConditionMet: "INTERMEDIATE_CONDITION_MET",
+	SCSIStatusReservationConflict:      "RESERVATION_CONFLICT",
+	SCSIStatusCommandTerminated:        "COMMAND_TERMINATED",
+	SCSIStatusTaskSetFull:              "TASK_SET_FULL",
+	SCSIStatusACAActive:                "ACA_ACTIVE",
+	SCSIStatusTaskAborted:              "TASK_ABORTED",
+}
+
+// TransactionFlow - TypeTransactionFlowRecord
+type TransactionFlow struct {
+	Direction     uint32 /* was this transaction observed by the server or the client */
+	Wait          uint32 /* time in microseconds that transaction was queued before processing started */
+	Duration      uint32 /* time in microseconds from start of processing to completion */
+	Status        uint32
+	BytesReceived uint64
+	BytesSent     uint64
+	DirectionName string `ignoreOnMarshal:"true"`
+	StatusName    string `ignoreOnMarshal:"true"`
+}
+
+func (f TransactionFlow) String() string {
+	type X TransactionFlow
+	x := X(f)
+	return fmt.Sprintf("TransactionFlow: %+v", x)
+}
+
+// RecordName returns the Name of this flow record
+func (f TransactionFlow) RecordName() string {
+	return "TransactionFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f TransactionFlow) RecordType() int {
+	return TypeTransactionFlowRecord
+}
+
+func (f TransactionFlow) calculateBinarySize() int {
+	var size int
+
+	size += binary.Size(f.Direction)
+	size += binary.Size(f.Wait)
+	size += binary.Size(f.Duration)
+	size += binary.Size(f.Status)
+	size += binary.Size(f.BytesReceived)
+	size += binary.Size(f.BytesSent)
+
+	return size
+}
+
+func (f *TransactionFlow) PostDecode() error {
+	f.DirectionName = lookupName(transactionDirectionNames, f.Direction)
+	f.StatusName = lookupName(transactionStatusNames, f.Status)
+
+	return nil
+}
+
+func (f TransactionFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
+}
+
+// ExtendedNFSStorageTransactionFlow - TypeExtendedNFSStorageTransactionFlowRecord
+type ExtendedNFSStorageTransactionFlow struct {
+	PathLen       uint32
+	Path          XDRString `lengthLookUp:"PathLen"` /* canonical path to file or directory associated with the operation */
+	Operation     uint32
+	Status        uint32
+	OperationName string `ignoreOnMarshal:"true"`
+	StatusName    string `ignoreOnMarshal:"true"`
+}
+
+func (f ExtendedNFSStorageTransactionFlow) String() string {
+	type X ExtendedNFSStorageTransactionFlow
+	x := X(f)
+	return fmt.Sprintf("ExtendedNFSStorageTransactionFlow: %+v", x)
+}
+
+// RecordName returns the Name of this flow record
+func (f ExtendedNFSStorageTransactionFlow) RecordName() string {
+	return "ExtendedNFSStorageTransactionFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f ExtendedNFSStorageTransactionFlow) RecordType() int {
+	return TypeExtendedNFSStorageTransactionFlowRecord
+}
+
+func (f ExtendedNFSStorageTransactionFlow) calculateBinarySize() int {
+	var size int
+
+	size += binary.Size(f.PathLen)
+	size += paddedLength(len(f.Path))
+	size += binary.Size(f.Operation)
+	size += binary.Size(f.Status)
+
+	return size
+}
+
+func (f *ExtendedNFSStorageTransactionFlow) PostDecode() error {
+	f.OperationName = lookupName(nfsOperationNames, f.Operation)
+	f.StatusName = lookupName(nfsStatusNames, f.Status)
+
+	return nil
+}
+
+func (f ExtendedNFSStorageTransactionFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
+}
+
+// ExtendedSCSIStorageTransactionFlow - TypeExtendedSCSIStorageTransactionFlowRecord
+type ExtendedSCSIStorageTransactionFlow struct {
+	LUN           uint32
+	Operation     uint32
+	Length        uint32 /* total amount of data transferred */
+	Status        uint32
+	OperationName string `ignoreOnMarshal:"true"`
+	StatusName    string `ignoreOnMarshal:"true"`
+}
+
+func (f ExtendedSCSIStorageTransactionFlow) String() string {
+	type X ExtendedSCSIStorageTransactionFlow
+	x := X(f)
+	return fmt.Sprintf("ExtendedSCSIStorageTransactionFlow: %+v", x)
+}
+
+// RecordName returns the Name of this flow record
+func (f ExtendedSCSIStorageTransactionFlow) RecordName() string {
+	return "ExtendedSCSIStorageTransactionFlow"
+}
+
+// RecordType returns the ID of the sflow flow record
+func (f ExtendedSCSIStorageTransactionFlow) RecordType() int {
+	return TypeExtendedSCSIStorageTransactionFlowRecord
+}
+
+func (f ExtendedSCSIStorageTransactionFlow) calculateBinarySize() int {
+	var size int
+
+	size += binary.Size(f.LUN)
+	size += binary.Size(f.Operation)
+	size += binary.Size(f.Length)
+	size += binary.Size(f.Status)
+
+	return size
+}
+
+func (f *ExtendedSCSIStorageTransactionFlow) PostDecode() error {
+	f.OperationName = lookupName(scsiOperationNames, f.Operation)
+	f.StatusName = lookupName(scsiStatusNames, f.Status)
+
+	return nil
+}
+
+func (f ExtendedSCSIStorageTransactionFlow) Encode(w io.Writer) error {
+	var err error
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.RecordType()))
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(f.calculateBinarySize()))
+	if err != nil {
+		return err
+	}
+
+	return Encode(w, f)
+}
@@ -0,0 +1,101 @@
+package records
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeTransactionFlowRecord(t *testing.T) {
+	rec := TransactionFlow{
+		Direction:     TransactionDirectionServer,
+		Wait:          20,
+		Duration:      3500,
+		Status:        TransactionStatusTimeout,
+		BytesReceived: 8192,
+		BytesSent:     1 << 20,
+		DirectionName: "server",
+		StatusName:    "timeout",
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+32 {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+32, b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeFlow(b, TypeTransactionFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestEncodeDecodeExtendedNFSStorageTransactionFlowRecord(t *testing.T) {
+	rec := ExtendedNFSStorageTransactionFlow{
+		PathLen:       22,
+		Path:          XDRString("/export/home/db.sqlite"),
+		Operation:     NFSOperationWrite,
+		Status:        NFSStatusNoSpc,
+		OperationName: "write",
+		StatusName:    "NFS3ERR_NOSPC",
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 8+rec.calculateBinarySize() {
+		t.Fatalf("expected %d encoded bytes, got %d", 8+rec.calculateBinarySize(), b.Len())
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeFlow(b, TypeExtendedNFSStorageTransactionFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
+
+func TestEncodeDecodeExtendedSCSIStorageTransactionFlowRecord(t *testing.T) {
+	rec := ExtendedSCSIStorageTransactionFlow{
+		LUN:           3,
+		Operation:     SCSIOperationUnknown,
+		Length:        4096,
+		Status:        SCSIStatusCheckCondition,
+		OperationName: "UNKNOWN",
+		StatusName:    "CHECK_CONDITION",
+	}
+
+	b := &bytes.Buffer{}
+
+	err := rec.Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SkipHeaderBytes(b)
+	decoded, err := DecodeFlow(b, TypeExtendedSCSIStorageTransactionFlowRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(rec, decoded) {
+		t.Errorf("expected\n%+#v\n, got\n%+#v", rec, decoded)
+	}
+}
@@ -0,0 +1,48 @@
+package records
+
+import (
+	"bytes"
+)
+
+// VLANTag is an IEEE 802.1Q tag as found in RawPacketFlow.Header
+type VLANTag struct {
+	TCI          uint16 `json:"tci"` /* tag control information */
+	Priority     uint8  `ignoreOnMarshal:"true" json:"priority"`
+	DropEligible bool   `ignoreOnMarshal:"true" json:"dropEligible"`
+	ID           uint16 `ignoreOnMarshal:"true" json:"id"`
+}
+
+// decodeVLANTag decodes an 802.1Q tag and returns it along with the EtherType of the encapsulated frame
+func decodeVLANTag(h *bytes.Reader, p *DecodedPacket) (VLANTag, uint16, error) {
+	tag := VLANTag{}
+	var typeLen uint16
+
+	x := p.headerReader(h)
+	tag.decodeXDR(&x)
+	x.uint16(&typeLen)
+	if err := x.skip(h); err != nil {
+		return tag, 0, err
+	}
+
+	tag.Priority = uint8(tag.TCI >> 13)
+	tag.DropEligible = tag.TCI&0x1000 != 0
+	tag.ID = tag.TCI & 0x0fff
+
+	return tag, typeLen, nil
+}
+
+// dissectVLANTag decodes an 802.1Q tag and the header following it from h into p
+func dissectVLANTag(h *bytes.Reader, p *DecodedPacket) error {
+	tag, typeLen, err := decodeVLANTag(h, p)
+	if err != nil {
+		return err
+	}
+
+	// The first tags are held by the storage of p
+	if p.VLANs == nil {
+		p.VLANs = p.storage.vlans[:0]
+	}
+	p.VLANs = append(p.VLANs, tag)
+
+	return decodeEtherType(typeLen, h, p)
+}
@@ -0,0 +1,354 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+)
+
+//go:generate go run ./internal/xdrgen -output xdr_gen.go -types EthernetHeader,VLANTag,LLCHeader,IPXHeader,ARPHeader,TokenRingHeader,FDDIHeader,FrameRelayHeader,IPv4Header,IPv6Header,GREHeader,ICMPHeader,TCPHeader,UDPHeader
+
+// XDRDecoder is implemented by records with a generated decoder. DecodeXDR reads the fields
+// in the same way as the struct tag driven decoding of decodeInto and returns the number of bytes read.
+type XDRDecoder interface {
+	DecodeXDR(r io.Reader) (int, error)
+}
+
+// XDREncoder is implemented by records with a generated encoder. EncodeXDR writes the fields
+// in the same way as the struct tag driven encoding of Encode.
+type XDREncoder interface {
+	EncodeXDR(w io.Writer) error
+}
+
+// ipVersionLength returns the length of an IP address of the given ipVersionLookUp type
+func ipVersionLength(ipType uint64) (int, error) {
+	switch ipType {
+	case 1:
+		return 4, nil
+	case 2:
+		return 16, nil
+	}
+	return 0, fmt.Errorf("Invalid Value found in ipVersionLookUp Type Field. Expected 1 or 2 and got: %d", ipType)
+}
+
+// maxLengthError returns the error for the named field of n elements, which is longer than max
+func maxLengthError(field string, n uint64, max int) error {
+	return fmt.Errorf("field %s length %d more than %d: %w", field, n, max, ErrMaximumLength)
+}
+
+// fieldMaxLength returns the maximum number of elements of a variable length field given by its maxLength tag
+func fieldMaxLength(field reflect.StructField) (int, error) {
+	tag := field.Tag.Get("maxLength")
+	if tag == "" {
+		return MaximumRecordLength, nil
+	}
+
+	max, err := strconv.Atoi(tag)
+	if err != nil || max < 0 {
+		return 0, fmt.Errorf("Invalid maxLength of field %s: %q", field.Name, tag)
+	}
+	return max, nil
+}
+
+// xdrReader is used by the generated decoders. It counts the bytes read and keeps the first error,
+// once an error occurred all further reads are skipped. Without a reader it decodes b in place:
+// opaque data, IP and MAC addresses then refer to b instead of being copied, so nothing is allocated.
+type xdrReader struct {
+	r   io.Reader
+	b   []byte
+	n   int
+	err error
+	buf []byte /* reads the integers of r */
+}
+
+// next returns the next n bytes, which are only valid until the next read
+func (x *xdrReader) next(n int) []byte {
+	if x.err != nil {
+		return nil
+	}
+
+	if x.r == nil {
+		if len(x.b)-x.n < n {
+			x.eof()
+			return nil
+		}
+
+		b := x.b[x.n : x.n+n : x.n+n]
+		x.n += n
+		return b
+	}
+
+	if x.buf == nil {
+		x.buf = make([]byte, 8)
+	}
+	x.read(x.buf[:n])
+	if x.err != nil {
+		return nil
+	}
+	return x.buf[:n]
+}
+
+// eof sets the error of io.ReadFull for the rest of b and consumes it
+func (x *xdrReader) eof() {
+	x.err = io.ErrUnexpectedEOF
+	if x.n == len(x.b) {
+		x.err = io.EOF
+	}
+	x.n = len(x.b)
+}
+
+func (x *xdrReader) read(b []byte) {
+	if x.err != nil {
+		return
+	}
+
+	if x.r == nil {
+		copy(b, x.next(len(b)))
+		return
+	}
+
+	n, err := io.ReadFull(x.r, b)
+	x.n += n
+	x.err = err
+}
+
+func (x *xdrReader) uint8(v *uint8) {
+	if b := x.next(1); b != nil {
+		*v = b[0]
+	}
+}
+
+func (x *xdrReader) uint16(v *uint16) {
+	if b := x.next(2); b != nil {
+		*v = binary.BigEndian.Uint16(b)
+	}
+}
+
+func (x *xdrReader) uint32(v *uint32) {
+	if b := x.next(4); b != nil {
+		*v = binary.BigEndian.Uint32(b)
+	}
+}
+
+func (x *xdrReader) uint64(v *uint64) {
+	if b := x.next(8); b != nil {
+		*v = binary.BigEndian.Uint64(b)
+	}
+}
+
+func (x *xdrReader) int32(v *int32) {
+	if b := x.next(4); b != nil {
+		*v = int32(binary.BigEndian.Uint32(b))
+	}
+}
+
+func (x *xdrReader) int64(v *int64) {
+	if b := x.next(8); b != nil {
+		*v = int64(binary.BigEndian.Uint64(b))
+	}
+}
+
+// length returns the number of elements n of the named field or sets an error if it is longer than max
+func (x *xdrReader) length(field string, n uint64, max int) int {
+	if x.err != nil {
+		return 0
+	}
+
+	if n > uint64(max) {
+		x.err = maxLengthError(field, n, max)
+		return 0
+	}
+	return int(n)
+}
+
+// bytes returns the next n bytes, which refer to b or are copied from r
+func (x *xdrReader) bytes(n int) []byte {
+	if x.err != nil {
+		return nil
+	}
+
+	if x.r == nil {
+		return x.next(n)
+	}
+
+	b := make([]byte, n)
+	x.read(b)
+	return b
+}
+
+// opaque reads n bytes of XDR opaque data or string, which is padded to a multiple of 4 bytes
+func (x *xdrReader) opaque(v *[]byte, n int) {
+	if x.err != nil || n == 0 {
+		return
+	}
+
+	b := x.bytes(paddedLength(n))
+	if x.err == nil {
+		*v = b[:n:n]
+	}
+}
+
+func (x *xdrReader) uint32s(v *[]uint32, n int) {
+	if x.err != nil || n == 0 {
+		return
+	}
+
+	s := make([]uint32, n)
+	for i := range s {
+		x.uint32(&s[i])
+	}
+	*v = s
+}
+
+func (x *xdrReader) int32s(v *[]int32, n int) {
+	if x.err != nil || n == 0 {
+		return
+	}
+
+	s := make([]int32, n)
+	for i := range s {
+		x.int32(&s[i])
+	}
+	*v = s
+}
+
+// ip reads an IP address of the given length
+func (x *xdrReader) ip(v *net.IP, n int) {
+	if x.err != nil {
+		return
+	}
+
+	*v = x.bytes(n)
+}
+
+// hardwareAddr reads a MAC address
+func (x *xdrReader) hardwareAddr(v *HardwareAddr) {
+	if x.err != nil {
+		return
+	}
+
+	*v = x.bytes(6)
+}
+
+// skip advances h past the bytes decoded in place from the unread part of h and returns the error of x
+func (x *xdrReader) skip(h *bytes.Reader) error {
+	if x.r == nil {
+		h.Seek(int64(x.n), io.SeekCurrent)
+	}
+	return x.err
+}
+
+// ipLength returns the length of an IP address of the given ipVersionLookUp type
+func (x *xdrReader) ipLength(ipType uint64) int {
+	if x.err != nil {
+		return 0
+	}
+
+	n, err := ipVersionLength(ipType)
+	x.err = err
+	return n
+}
+
+// xdrWriter is used by the generated encoders. It keeps the first error,
+// once an error occurred all further writes are skipped.
+type xdrWriter struct {
+	w   io.Writer
+	err error
+	buf [8]byte
+}
+
+// padding holds the zeros written after opaque data
+var padding [3]byte
+
+func (x *xdrWriter) write(b []byte) {
+	if x.err != nil {
+		return
+	}
+
+	_, x.err = x.w.Write(b)
+}
+
+func (x *xdrWriter) uint8(v uint8) {
+	x.buf[0] = v
+	x.write(x.buf[:1])
+}
+
+func (x *xdrWriter) uint16(v uint16) {
+	binary.BigEndian.PutUint16(x.buf[:2], v)
+	x.write(x.buf[:2])
+}
+
+func (x *xdrWriter) uint32(v uint32) {
+	binary.BigEndian.PutUint32(x.buf[:4], v)
+	x.write(x.buf[:4])
+}
+
+func (x *xdrWriter) uint64(v uint64) {
+	binary.BigEndian.PutUint64(x.buf[:8], v)
+	x.write(x.buf[:8])
+}
+
+func (x *xdrWriter) int32(v int32) {
+	x.uint32(uint32(v))
+}
+
+func (x *xdrWriter) int64(v int64) {
+	x.uint64(uint64(v))
+}
+
+// length sets an error if the named field of n elements is longer than max
+func (x *xdrWriter) length(field string, n int, max int) {
+	if x.err == nil && n > max {
+		x.err = maxLengthError(field, uint64(n), max)
+	}
+}
+
+// opaque writes XDR opaque data or string padded to a multiple of 4 bytes
+func (x *xdrWriter) opaque(b []byte) {
+	x.write(b)
+	x.write(padding[:paddedLength(len(b))-len(b)])
+}
+
+func (x *xdrWriter) uint32s(s []uint32) {
+	for _, v := range s {
+		x.uint32(v)
+	}
+}
+
+func (x *xdrWriter) int32s(s []int32) {
+	for _, v := range s {
+		x.int32(v)
+	}
+}
+
+// ip writes an IP address of the given length. IPv4 addresses held in 16 bytes are written as 4 bytes.
+func (x *xdrWriter) ip(ip net.IP, n int) {
+	if n == 4 && len(ip) == 16 {
+		ip = ip[12:]
+	}
+	x.write(ip)
+}
+
+// ipLength returns the length of an IP address of the given ipVersionLookUp type
+func (x *xdrWriter) ipLength(ipType uint64) int {
+	if x.err != nil {
+		return 0
+	}
+
+	n, err := ipVersionLength(ipType)
+	x.err = err
+	return n
+}
+
+// encode writes a nested struct with its generated encoder
+func (x *xdrWriter) encode(e XDREncoder) {
+	if x.err != nil {
+		return
+	}
+
+	x.err = e.EncodeXDR(x.w)
+}
@@ -0,0 +1,1910 @@
+// Code generated by xdrgen from the struct tags of the records. DO NOT EDIT.
+
+package records
+
+import (
+	"io"
+	"sync"
+)
+
+// DecodeXDR decodes EthernetFrameFlow from r and returns the number of bytes read
+func (f *EthernetFrameFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *EthernetFrameFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.Dot3StatsAlignmentErrors)
+	x.uint32(&f.Dot3StatsFCSErrors)
+	x.uint32(&f.Dot3StatsSingleCollisionFrames)
+	x.uint32(&f.Dot3StatsMultipleCollisionFrames)
+	x.uint32(&f.Dot3StatsSQETestErrors)
+	x.uint32(&f.Dot3StatsDeferredTransmissions)
+	x.uint32(&f.Dot3StatsLateCollisions)
+	x.uint32(&f.Dot3StatsExcessiveCollisions)
+	x.uint32(&f.Dot3StatsInternalMacTransmitErrors)
+	x.uint32(&f.Dot3StatsCarrierSenseErrors)
+	x.uint32(&f.Dot3StatsFrameTooLongs)
+	x.uint32(&f.Dot3StatsInternalMacReceiveErrors)
+	x.uint32(&f.Dot3StatsSymbolErrors)
+}
+
+// EncodeXDR writes the XDR representation of EthernetFrameFlow to w
+func (f EthernetFrameFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.Dot3StatsAlignmentErrors)
+	x.uint32(f.Dot3StatsFCSErrors)
+	x.uint32(f.Dot3StatsSingleCollisionFrames)
+	x.uint32(f.Dot3StatsMultipleCollisionFrames)
+	x.uint32(f.Dot3StatsSQETestErrors)
+	x.uint32(f.Dot3StatsDeferredTransmissions)
+	x.uint32(f.Dot3StatsLateCollisions)
+	x.uint32(f.Dot3StatsExcessiveCollisions)
+	x.uint32(f.Dot3StatsInternalMacTransmitErrors)
+	x.uint32(f.Dot3StatsCarrierSenseErrors)
+	x.uint32(f.Dot3StatsFrameTooLongs)
+	x.uint32(f.Dot3StatsInternalMacReceiveErrors)
+	x.uint32(f.Dot3StatsSymbolErrors)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedSwitchFlow from r and returns the number of bytes read
+func (f *ExtendedSwitchFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedSwitchFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.SourceVlan)
+	x.uint32(&f.SourcePriority)
+	x.uint32(&f.DestinationVlan)
+	x.uint32(&f.DestinationPriority)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedSwitchFlow to w
+func (f ExtendedSwitchFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.SourceVlan)
+	x.uint32(f.SourcePriority)
+	x.uint32(f.DestinationVlan)
+	x.uint32(f.DestinationPriority)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedRouterFlow from r and returns the number of bytes read
+func (f *ExtendedRouterFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedRouterFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.NextHopType)
+	x.ip(&f.NextHop, x.ipLength(uint64(f.NextHopType)))
+	x.uint32(&f.SrcMask)
+	x.uint32(&f.DstMask)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedRouterFlow to w
+func (f ExtendedRouterFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.NextHopType)
+	x.ip(f.NextHop, x.ipLength(uint64(f.NextHopType)))
+	x.uint32(f.SrcMask)
+	x.uint32(f.DstMask)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedGatewayFlowASPathSegment from r and returns the number of bytes read
+func (f *ExtendedGatewayFlowASPathSegment) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedGatewayFlowASPathSegment) decodeXDR(x *xdrReader) {
+	x.uint32(&f.SegType)
+	x.uint32(&f.SegLen)
+	x.uint32s(&f.Seg, x.length("ExtendedGatewayFlowASPathSegment.Seg", uint64(f.SegLen), MaximumRecordLength))
+}
+
+// EncodeXDR writes the XDR representation of ExtendedGatewayFlowASPathSegment to w
+func (f ExtendedGatewayFlowASPathSegment) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.SegType)
+	x.uint32(uint32(len(f.Seg)))
+	x.length("ExtendedGatewayFlowASPathSegment.Seg", len(f.Seg), MaximumRecordLength)
+	x.uint32s(f.Seg)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedGatewayFlow from r and returns the number of bytes read
+func (f *ExtendedGatewayFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedGatewayFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.NextHopType)
+	x.ip(&f.NextHop, x.ipLength(uint64(f.NextHopType)))
+	x.uint32(&f.As)
+	x.uint32(&f.SrcAs)
+	x.uint32(&f.SrcPeerAs)
+	x.uint32(&f.DstAsPathSegmentsLen)
+	if n := x.length("ExtendedGatewayFlow.DstAsPathSegments", uint64(f.DstAsPathSegmentsLen), MaximumRecordLength); n > 0 {
+		f.DstAsPathSegments = make([]ExtendedGatewayFlowASPathSegment, n)
+		for i := range f.DstAsPathSegments {
+			f.DstAsPathSegments[i].decodeXDR(x)
+		}
+	}
+	x.uint32(&f.CommunitiesLen)
+	x.uint32s(&f.Communities, x.length("ExtendedGatewayFlow.Communities", uint64(f.CommunitiesLen), MaximumRecordLength))
+	x.uint32(&f.LocalPref)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedGatewayFlow to w
+func (f ExtendedGatewayFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.NextHopType)
+	x.ip(f.NextHop, x.ipLength(uint64(f.NextHopType)))
+	x.uint32(f.As)
+	x.uint32(f.SrcAs)
+	x.uint32(f.SrcPeerAs)
+	x.uint32(uint32(len(f.DstAsPathSegments)))
+	x.length("ExtendedGatewayFlow.DstAsPathSegments", len(f.DstAsPathSegments), MaximumRecordLength)
+	for _, e := range f.DstAsPathSegments {
+		x.encode(e)
+	}
+	x.uint32(uint32(len(f.Communities)))
+	x.length("ExtendedGatewayFlow.Communities", len(f.Communities), MaximumRecordLength)
+	x.uint32s(f.Communities)
+	x.uint32(f.LocalPref)
+	return x.err
+}
+
+// DecodeXDR decodes TransactionFlow from r and returns the number of bytes read
+func (f *TransactionFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *TransactionFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.Direction)
+	x.uint32(&f.Wait)
+	x.uint32(&f.Duration)
+	x.uint32(&f.Status)
+	x.uint64(&f.BytesReceived)
+	x.uint64(&f.BytesSent)
+}
+
+// EncodeXDR writes the XDR representation of TransactionFlow to w
+func (f TransactionFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.Direction)
+	x.uint32(f.Wait)
+	x.uint32(f.Duration)
+	x.uint32(f.Status)
+	x.uint64(f.BytesReceived)
+	x.uint64(f.BytesSent)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedNFSStorageTransactionFlow from r and returns the number of bytes read
+func (f *ExtendedNFSStorageTransactionFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedNFSStorageTransactionFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.PathLen)
+	x.opaque((*[]byte)(&f.Path), x.length("ExtendedNFSStorageTransactionFlow.Path", uint64(f.PathLen), MaximumRecordLength))
+	x.uint32(&f.Operation)
+	x.uint32(&f.Status)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedNFSStorageTransactionFlow to w
+func (f ExtendedNFSStorageTransactionFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(uint32(len(f.Path)))
+	x.length("ExtendedNFSStorageTransactionFlow.Path", len(f.Path), MaximumRecordLength)
+	x.opaque([]byte(f.Path))
+	x.uint32(f.Operation)
+	x.uint32(f.Status)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedSCSIStorageTransactionFlow from r and returns the number of bytes read
+func (f *ExtendedSCSIStorageTransactionFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedSCSIStorageTransactionFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.LUN)
+	x.uint32(&f.Operation)
+	x.uint32(&f.Length)
+	x.uint32(&f.Status)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedSCSIStorageTransactionFlow to w
+func (f ExtendedSCSIStorageTransactionFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.LUN)
+	x.uint32(f.Operation)
+	x.uint32(f.Length)
+	x.uint32(f.Status)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedSocketIPv4Flow from r and returns the number of bytes read
+func (f *ExtendedSocketIPv4Flow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedSocketIPv4Flow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.Protocol)
+	x.ip(&f.LocalIP, 4)
+	x.ip(&f.RemoteIP, 4)
+	x.uint32(&f.LocalPort)
+	x.uint32(&f.RemotePort)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedSocketIPv4Flow to w
+func (f ExtendedSocketIPv4Flow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.Protocol)
+	x.ip(f.LocalIP, 4)
+	x.ip(f.RemoteIP, 4)
+	x.uint32(f.LocalPort)
+	x.uint32(f.RemotePort)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedSocketIPv6Flow from r and returns the number of bytes read
+func (f *ExtendedSocketIPv6Flow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedSocketIPv6Flow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.Protocol)
+	x.ip(&f.LocalIP, 16)
+	x.ip(&f.RemoteIP, 16)
+	x.uint32(&f.LocalPort)
+	x.uint32(&f.RemotePort)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedSocketIPv6Flow to w
+func (f ExtendedSocketIPv6Flow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.Protocol)
+	x.ip(f.LocalIP, 16)
+	x.ip(f.RemoteIP, 16)
+	x.uint32(f.LocalPort)
+	x.uint32(f.RemotePort)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedProxySocketIPv4Flow from r and returns the number of bytes read
+func (f *ExtendedProxySocketIPv4Flow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedProxySocketIPv4Flow) decodeXDR(x *xdrReader) {
+	f.Socket.decodeXDR(x)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedProxySocketIPv4Flow to w
+func (f ExtendedProxySocketIPv4Flow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.encode(f.Socket)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedProxySocketIPv6Flow from r and returns the number of bytes read
+func (f *ExtendedProxySocketIPv6Flow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedProxySocketIPv6Flow) decodeXDR(x *xdrReader) {
+	f.Socket.decodeXDR(x)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedProxySocketIPv6Flow to w
+func (f ExtendedProxySocketIPv6Flow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.encode(f.Socket)
+	return x.err
+}
+
+// DecodeXDR decodes MemcacheOperationFlow from r and returns the number of bytes read
+func (f *MemcacheOperationFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *MemcacheOperationFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.Protocol)
+	x.uint32(&f.Cmd)
+	x.uint32(&f.KeyLen)
+	x.opaque((*[]byte)(&f.Key), x.length("MemcacheOperationFlow.Key", uint64(f.KeyLen), 255))
+	x.uint32(&f.NKeys)
+	x.uint32(&f.ValueBytes)
+	x.uint32(&f.Duration)
+	x.uint32(&f.Status)
+}
+
+// EncodeXDR writes the XDR representation of MemcacheOperationFlow to w
+func (f MemcacheOperationFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.Protocol)
+	x.uint32(f.Cmd)
+	x.uint32(uint32(len(f.Key)))
+	x.length("MemcacheOperationFlow.Key", len(f.Key), 255)
+	x.opaque([]byte(f.Key))
+	x.uint32(f.NKeys)
+	x.uint32(f.ValueBytes)
+	x.uint32(f.Duration)
+	x.uint32(f.Status)
+	return x.err
+}
+
+// DecodeXDR decodes AppContext from r and returns the number of bytes read
+func (f *AppContext) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *AppContext) decodeXDR(x *xdrReader) {
+	x.uint32(&f.ApplicationLen)
+	x.opaque((*[]byte)(&f.Application), x.length("AppContext.Application", uint64(f.ApplicationLen), 32))
+	x.uint32(&f.OperationLen)
+	x.opaque((*[]byte)(&f.Operation), x.length("AppContext.Operation", uint64(f.OperationLen), 32))
+	x.uint32(&f.AttributesLen)
+	x.opaque((*[]byte)(&f.Attributes), x.length("AppContext.Attributes", uint64(f.AttributesLen), 255))
+}
+
+// EncodeXDR writes the XDR representation of AppContext to w
+func (f AppContext) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(uint32(len(f.Application)))
+	x.length("AppContext.Application", len(f.Application), 32)
+	x.opaque([]byte(f.Application))
+	x.uint32(uint32(len(f.Operation)))
+	x.length("AppContext.Operation", len(f.Operation), 32)
+	x.opaque([]byte(f.Operation))
+	x.uint32(uint32(len(f.Attributes)))
+	x.length("AppContext.Attributes", len(f.Attributes), 255)
+	x.opaque([]byte(f.Attributes))
+	return x.err
+}
+
+// DecodeXDR decodes AppOperationFlow from r and returns the number of bytes read
+func (f *AppOperationFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *AppOperationFlow) decodeXDR(x *xdrReader) {
+	f.Context.decodeXDR(x)
+	x.uint32(&f.StatusDescrLen)
+	x.opaque((*[]byte)(&f.StatusDescr), x.length("AppOperationFlow.StatusDescr", uint64(f.StatusDescrLen), 64))
+	x.uint64(&f.ReqBytes)
+	x.uint64(&f.RespBytes)
+	x.uint32(&f.Duration)
+	x.uint32(&f.Status)
+}
+
+// EncodeXDR writes the XDR representation of AppOperationFlow to w
+func (f AppOperationFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.encode(f.Context)
+	x.uint32(uint32(len(f.StatusDescr)))
+	x.length("AppOperationFlow.StatusDescr", len(f.StatusDescr), 64)
+	x.opaque([]byte(f.StatusDescr))
+	x.uint64(f.ReqBytes)
+	x.uint64(f.RespBytes)
+	x.uint32(f.Duration)
+	x.uint32(f.Status)
+	return x.err
+}
+
+// DecodeXDR decodes AppParentContextFlow from r and returns the number of bytes read
+func (f *AppParentContextFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *AppParentContextFlow) decodeXDR(x *xdrReader) {
+	f.Context.decodeXDR(x)
+}
+
+// EncodeXDR writes the XDR representation of AppParentContextFlow to w
+func (f AppParentContextFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.encode(f.Context)
+	return x.err
+}
+
+// DecodeXDR decodes AppInitiatorFlow from r and returns the number of bytes read
+func (f *AppInitiatorFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *AppInitiatorFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.ActorLen)
+	x.opaque((*[]byte)(&f.Actor), x.length("AppInitiatorFlow.Actor", uint64(f.ActorLen), 64))
+}
+
+// EncodeXDR writes the XDR representation of AppInitiatorFlow to w
+func (f AppInitiatorFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(uint32(len(f.Actor)))
+	x.length("AppInitiatorFlow.Actor", len(f.Actor), 64)
+	x.opaque([]byte(f.Actor))
+	return x.err
+}
+
+// DecodeXDR decodes AppTargetFlow from r and returns the number of bytes read
+func (f *AppTargetFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *AppTargetFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.ActorLen)
+	x.opaque((*[]byte)(&f.Actor), x.length("AppTargetFlow.Actor", uint64(f.ActorLen), 64))
+}
+
+// EncodeXDR writes the XDR representation of AppTargetFlow to w
+func (f AppTargetFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(uint32(len(f.Actor)))
+	x.length("AppTargetFlow.Actor", len(f.Actor), 64)
+	x.opaque([]byte(f.Actor))
+	return x.err
+}
+
+// DecodeXDR decodes HTTPRequestFlow from r and returns the number of bytes read
+func (f *HTTPRequestFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *HTTPRequestFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.Method)
+	x.uint32(&f.Protocol)
+	x.uint32(&f.URILen)
+	x.opaque((*[]byte)(&f.URI), x.length("HTTPRequestFlow.URI", uint64(f.URILen), 255))
+	x.uint32(&f.HostLen)
+	x.opaque((*[]byte)(&f.Host), x.length("HTTPRequestFlow.Host", uint64(f.HostLen), 64))
+	x.uint32(&f.RefererLen)
+	x.opaque((*[]byte)(&f.Referer), x.length("HTTPRequestFlow.Referer", uint64(f.RefererLen), 255))
+	x.uint32(&f.UserAgentLen)
+	x.opaque((*[]byte)(&f.UserAgent), x.length("HTTPRequestFlow.UserAgent", uint64(f.UserAgentLen), 128))
+	x.uint32(&f.XFFLen)
+	x.opaque((*[]byte)(&f.XFF), x.length("HTTPRequestFlow.XFF", uint64(f.XFFLen), 64))
+	x.uint32(&f.AuthUserLen)
+	x.opaque((*[]byte)(&f.AuthUser), x.length("HTTPRequestFlow.AuthUser", uint64(f.AuthUserLen), 32))
+	x.uint32(&f.MimeTypeLen)
+	x.opaque((*[]byte)(&f.MimeType), x.length("HTTPRequestFlow.MimeType", uint64(f.MimeTypeLen), 64))
+	x.uint64(&f.ReqBytes)
+	x.uint64(&f.RespBytes)
+	x.uint32(&f.Duration)
+	x.int32(&f.Status)
+}
+
+// EncodeXDR writes the XDR representation of HTTPRequestFlow to w
+func (f HTTPRequestFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.Method)
+	x.uint32(f.Protocol)
+	x.uint32(uint32(len(f.URI)))
+	x.length("HTTPRequestFlow.URI", len(f.URI), 255)
+	x.opaque([]byte(f.URI))
+	x.uint32(uint32(len(f.Host)))
+	x.length("HTTPRequestFlow.Host", len(f.Host), 64)
+	x.opaque([]byte(f.Host))
+	x.uint32(uint32(len(f.Referer)))
+	x.length("HTTPRequestFlow.Referer", len(f.Referer), 255)
+	x.opaque([]byte(f.Referer))
+	x.uint32(uint32(len(f.UserAgent)))
+	x.length("HTTPRequestFlow.UserAgent", len(f.UserAgent), 128)
+	x.opaque([]byte(f.UserAgent))
+	x.uint32(uint32(len(f.XFF)))
+	x.length("HTTPRequestFlow.XFF", len(f.XFF), 64)
+	x.opaque([]byte(f.XFF))
+	x.uint32(uint32(len(f.AuthUser)))
+	x.length("HTTPRequestFlow.AuthUser", len(f.AuthUser), 32)
+	x.opaque([]byte(f.AuthUser))
+	x.uint32(uint32(len(f.MimeType)))
+	x.length("HTTPRequestFlow.MimeType", len(f.MimeType), 64)
+	x.opaque([]byte(f.MimeType))
+	x.uint64(f.ReqBytes)
+	x.uint64(f.RespBytes)
+	x.uint32(f.Duration)
+	x.int32(f.Status)
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedProxyRequestFlow from r and returns the number of bytes read
+func (f *ExtendedProxyRequestFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedProxyRequestFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.URILen)
+	x.opaque((*[]byte)(&f.URI), x.length("ExtendedProxyRequestFlow.URI", uint64(f.URILen), 255))
+	x.uint32(&f.HostLen)
+	x.opaque((*[]byte)(&f.Host), x.length("ExtendedProxyRequestFlow.Host", uint64(f.HostLen), 64))
+}
+
+// EncodeXDR writes the XDR representation of ExtendedProxyRequestFlow to w
+func (f ExtendedProxyRequestFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(uint32(len(f.URI)))
+	x.length("ExtendedProxyRequestFlow.URI", len(f.URI), 255)
+	x.opaque([]byte(f.URI))
+	x.uint32(uint32(len(f.Host)))
+	x.length("ExtendedProxyRequestFlow.Host", len(f.Host), 64)
+	x.opaque([]byte(f.Host))
+	return x.err
+}
+
+// DecodeXDR decodes ExtendedNavTimingFlow from r and returns the number of bytes read
+func (f *ExtendedNavTimingFlow) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ExtendedNavTimingFlow) decodeXDR(x *xdrReader) {
+	x.uint32(&f.Type)
+	x.uint32(&f.RedirectCount)
+	x.uint32(&f.NavigationStart)
+	x.uint32(&f.UnloadEventStart)
+	x.uint32(&f.UnloadEventEnd)
+	x.uint32(&f.RedirectStart)
+	x.uint32(&f.RedirectEnd)
+	x.uint32(&f.FetchStart)
+	x.uint32(&f.DomainLookupStart)
+	x.uint32(&f.DomainLookupEnd)
+	x.uint32(&f.ConnectStart)
+	x.uint32(&f.ConnectEnd)
+	x.uint32(&f.SecureConnectionStart)
+	x.uint32(&f.RequestStart)
+	x.uint32(&f.ResponseStart)
+	x.uint32(&f.ResponseEnd)
+	x.uint32(&f.DomLoading)
+	x.uint32(&f.DomInteractive)
+	x.uint32(&f.DomContentLoadedEventStart)
+	x.uint32(&f.DomContentLoadedEventEnd)
+	x.uint32(&f.DomComplete)
+	x.uint32(&f.LoadEventStart)
+	x.uint32(&f.LoadEventEnd)
+}
+
+// EncodeXDR writes the XDR representation of ExtendedNavTimingFlow to w
+func (f ExtendedNavTimingFlow) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(f.Type)
+	x.uint32(f.RedirectCount)
+	x.uint32(f.NavigationStart)
+	x.uint32(f.UnloadEventStart)
+	x.uint32(f.UnloadEventEnd)
+	x.uint32(f.RedirectStart)
+	x.uint32(f.RedirectEnd)
+	x.uint32(f.FetchStart)
+	x.uint32(f.DomainLookupStart)
+	x.uint32(f.DomainLookupEnd)
+	x.uint32(f.ConnectStart)
+	x.uint32(f.ConnectEnd)
+	x.uint32(f.SecureConnectionStart)
+	x.uint32(f.RequestStart)
+	x.uint32(f.ResponseStart)
+	x.uint32(f.ResponseEnd)
+	x.uint32(f.DomLoading)
+	x.uint32(f.DomInteractive)
+	x.uint32(f.DomContentLoadedEventStart)
+	x.uint32(f.DomContentLoadedEventEnd)
+	x.uint32(f.DomComplete)
+	x.uint32(f.LoadEventStart)
+	x.uint32(f.LoadEventEnd)
+	return x.err
+}
+
+// DecodeXDR decodes JMXRuntimeCounter from r and returns the number of bytes read
+func (c *JMXRuntimeCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *JMXRuntimeCounter) decodeXDR(x *xdrReader) {
+	x.uint32(&c.VMNameLen)
+	x.opaque((*[]byte)(&c.VMName), x.length("JMXRuntimeCounter.VMName", uint64(c.VMNameLen), 64))
+	x.uint32(&c.VMVendorLen)
+	x.opaque((*[]byte)(&c.VMVendor), x.length("JMXRuntimeCounter.VMVendor", uint64(c.VMVendorLen), 32))
+	x.uint32(&c.VMVersionLen)
+	x.opaque((*[]byte)(&c.VMVersion), x.length("JMXRuntimeCounter.VMVersion", uint64(c.VMVersionLen), 32))
+}
+
+// EncodeXDR writes the XDR representation of JMXRuntimeCounter to w
+func (c JMXRuntimeCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(uint32(len(c.VMName)))
+	x.length("JMXRuntimeCounter.VMName", len(c.VMName), 64)
+	x.opaque([]byte(c.VMName))
+	x.uint32(uint32(len(c.VMVendor)))
+	x.length("JMXRuntimeCounter.VMVendor", len(c.VMVendor), 32)
+	x.opaque([]byte(c.VMVendor))
+	x.uint32(uint32(len(c.VMVersion)))
+	x.length("JMXRuntimeCounter.VMVersion", len(c.VMVersion), 32)
+	x.opaque([]byte(c.VMVersion))
+	return x.err
+}
+
+// DecodeXDR decodes JMXStatisticsCounter from r and returns the number of bytes read
+func (c *JMXStatisticsCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *JMXStatisticsCounter) decodeXDR(x *xdrReader) {
+	x.uint64(&c.HeapInitial)
+	x.uint64(&c.HeapUsed)
+	x.uint64(&c.HeapCommitted)
+	x.uint64(&c.HeapMax)
+	x.uint64(&c.NonHeapInitial)
+	x.uint64(&c.NonHeapUsed)
+	x.uint64(&c.NonHeapCommitted)
+	x.uint64(&c.NonHeapMax)
+	x.uint32(&c.GCCount)
+	x.uint32(&c.GCTime)
+	x.uint32(&c.ClassesLoaded)
+	x.uint32(&c.ClassesTotal)
+	x.uint32(&c.ClassesUnloaded)
+	x.uint32(&c.CompilationTime)
+	x.uint32(&c.ThreadNumLive)
+	x.uint32(&c.ThreadNumDaemon)
+	x.uint32(&c.ThreadNumStarted)
+	x.uint32(&c.FileDescOpenCount)
+	x.uint32(&c.FileDescMaxCount)
+}
+
+// EncodeXDR writes the XDR representation of JMXStatisticsCounter to w
+func (c JMXStatisticsCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint64(c.HeapInitial)
+	x.uint64(c.HeapUsed)
+	x.uint64(c.HeapCommitted)
+	x.uint64(c.HeapMax)
+	x.uint64(c.NonHeapInitial)
+	x.uint64(c.NonHeapUsed)
+	x.uint64(c.NonHeapCommitted)
+	x.uint64(c.NonHeapMax)
+	x.uint32(c.GCCount)
+	x.uint32(c.GCTime)
+	x.uint32(c.ClassesLoaded)
+	x.uint32(c.ClassesTotal)
+	x.uint32(c.ClassesUnloaded)
+	x.uint32(c.CompilationTime)
+	x.uint32(c.ThreadNumLive)
+	x.uint32(c.ThreadNumDaemon)
+	x.uint32(c.ThreadNumStarted)
+	x.uint32(c.FileDescOpenCount)
+	x.uint32(c.FileDescMaxCount)
+	return x.err
+}
+
+// DecodeXDR decodes HTTPCounter from r and returns the number of bytes read
+func (c *HTTPCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *HTTPCounter) decodeXDR(x *xdrReader) {
+	x.uint32(&c.MethodOptionCount)
+	x.uint32(&c.MethodGetCount)
+	x.uint32(&c.MethodHeadCount)
+	x.uint32(&c.MethodPostCount)
+	x.uint32(&c.MethodPutCount)
+	x.uint32(&c.MethodDeleteCount)
+	x.uint32(&c.MethodTraceCount)
+	x.uint32(&c.MethodConnectCount)
+	x.uint32(&c.MethodOtherCount)
+	x.uint32(&c.Status1XXCount)
+	x.uint32(&c.Status2XXCount)
+	x.uint32(&c.Status3XXCount)
+	x.uint32(&c.Status4XXCount)
+	x.uint32(&c.Status5XXCount)
+	x.uint32(&c.StatusOtherCount)
+}
+
+// EncodeXDR writes the XDR representation of HTTPCounter to w
+func (c HTTPCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(c.MethodOptionCount)
+	x.uint32(c.MethodGetCount)
+	x.uint32(c.MethodHeadCount)
+	x.uint32(c.MethodPostCount)
+	x.uint32(c.MethodPutCount)
+	x.uint32(c.MethodDeleteCount)
+	x.uint32(c.MethodTraceCount)
+	x.uint32(c.MethodConnectCount)
+	x.uint32(c.MethodOtherCount)
+	x.uint32(c.Status1XXCount)
+	x.uint32(c.Status2XXCount)
+	x.uint32(c.Status3XXCount)
+	x.uint32(c.Status4XXCount)
+	x.uint32(c.Status5XXCount)
+	x.uint32(c.StatusOtherCount)
+	return x.err
+}
+
+// DecodeXDR decodes AppOperationsCounter from r and returns the number of bytes read
+func (c *AppOperationsCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *AppOperationsCounter) decodeXDR(x *xdrReader) {
+	x.uint32(&c.ApplicationLen)
+	x.opaque((*[]byte)(&c.Application), x.length("AppOperationsCounter.Application", uint64(c.ApplicationLen), 32))
+	x.uint32(&c.Success)
+	x.uint32(&c.Other)
+	x.uint32(&c.Timeout)
+	x.uint32(&c.InternalError)
+	x.uint32(&c.BadRequest)
+	x.uint32(&c.Forbidden)
+	x.uint32(&c.TooLarge)
+	x.uint32(&c.NotImplemented)
+	x.uint32(&c.NotFound)
+	x.uint32(&c.Unavailable)
+	x.uint32(&c.Unauthorized)
+}
+
+// EncodeXDR writes the XDR representation of AppOperationsCounter to w
+func (c AppOperationsCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(uint32(len(c.Application)))
+	x.length("AppOperationsCounter.Application", len(c.Application), 32)
+	x.opaque([]byte(c.Application))
+	x.uint32(c.Success)
+	x.uint32(c.Other)
+	x.uint32(c.Timeout)
+	x.uint32(c.InternalError)
+	x.uint32(c.BadRequest)
+	x.uint32(c.Forbidden)
+	x.uint32(c.TooLarge)
+	x.uint32(c.NotImplemented)
+	x.uint32(c.NotFound)
+	x.uint32(c.Unavailable)
+	x.uint32(c.Unauthorized)
+	return x.err
+}
+
+// DecodeXDR decodes AppResourcesCounter from r and returns the number of bytes read
+func (c *AppResourcesCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *AppResourcesCounter) decodeXDR(x *xdrReader) {
+	x.uint32(&c.UserTime)
+	x.uint32(&c.SystemTime)
+	x.uint64(&c.MemUsed)
+	x.uint64(&c.MemMax)
+	x.uint32(&c.FdOpen)
+	x.uint32(&c.FdMax)
+	x.uint32(&c.ConnOpen)
+	x.uint32(&c.ConnMax)
+}
+
+// EncodeXDR writes the XDR representation of AppResourcesCounter to w
+func (c AppResourcesCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(c.UserTime)
+	x.uint32(c.SystemTime)
+	x.uint64(c.MemUsed)
+	x.uint64(c.MemMax)
+	x.uint32(c.FdOpen)
+	x.uint32(c.FdMax)
+	x.uint32(c.ConnOpen)
+	x.uint32(c.ConnMax)
+	return x.err
+}
+
+// DecodeXDR decodes MemcacheCounter from r and returns the number of bytes read
+func (c *MemcacheCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *MemcacheCounter) decodeXDR(x *xdrReader) {
+	x.uint32(&c.CmdSet)
+	x.uint32(&c.CmdTouch)
+	x.uint32(&c.CmdFlush)
+	x.uint32(&c.GetHits)
+	x.uint32(&c.GetMisses)
+	x.uint32(&c.DeleteHits)
+	x.uint32(&c.DeleteMisses)
+	x.uint32(&c.IncrHits)
+	x.uint32(&c.IncrMisses)
+	x.uint32(&c.DecrHits)
+	x.uint32(&c.DecrMisses)
+	x.uint32(&c.CasHits)
+	x.uint32(&c.CasMisses)
+	x.uint32(&c.CasBadval)
+	x.uint32(&c.AuthCmds)
+	x.uint32(&c.AuthErrors)
+	x.uint32(&c.Threads)
+	x.uint32(&c.ConnYields)
+	x.uint32(&c.ListenDisabledNum)
+	x.uint32(&c.CurrConnections)
+	x.uint32(&c.RejectedConnections)
+	x.uint32(&c.TotalConnections)
+	x.uint32(&c.ConnectionStructures)
+	x.uint32(&c.Evictions)
+	x.uint32(&c.Reclaimed)
+	x.uint32(&c.CurrItems)
+	x.uint32(&c.TotalItems)
+	x.uint64(&c.BytesRead)
+	x.uint64(&c.BytesWritten)
+	x.uint64(&c.Bytes)
+	x.uint64(&c.LimitMaxbytes)
+}
+
+// EncodeXDR writes the XDR representation of MemcacheCounter to w
+func (c MemcacheCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(c.CmdSet)
+	x.uint32(c.CmdTouch)
+	x.uint32(c.CmdFlush)
+	x.uint32(c.GetHits)
+	x.uint32(c.GetMisses)
+	x.uint32(c.DeleteHits)
+	x.uint32(c.DeleteMisses)
+	x.uint32(c.IncrHits)
+	x.uint32(c.IncrMisses)
+	x.uint32(c.DecrHits)
+	x.uint32(c.DecrMisses)
+	x.uint32(c.CasHits)
+	x.uint32(c.CasMisses)
+	x.uint32(c.CasBadval)
+	x.uint32(c.AuthCmds)
+	x.uint32(c.AuthErrors)
+	x.uint32(c.Threads)
+	x.uint32(c.ConnYields)
+	x.uint32(c.ListenDisabledNum)
+	x.uint32(c.CurrConnections)
+	x.uint32(c.RejectedConnections)
+	x.uint32(c.TotalConnections)
+	x.uint32(c.ConnectionStructures)
+	x.uint32(c.Evictions)
+	x.uint32(c.Reclaimed)
+	x.uint32(c.CurrItems)
+	x.uint32(c.TotalItems)
+	x.uint64(c.BytesRead)
+	x.uint64(c.BytesWritten)
+	x.uint64(c.Bytes)
+	x.uint64(c.LimitMaxbytes)
+	return x.err
+}
+
+// DecodeXDR decodes AppWorkersCounter from r and returns the number of bytes read
+func (c *AppWorkersCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *AppWorkersCounter) decodeXDR(x *xdrReader) {
+	x.uint32(&c.WorkersActive)
+	x.uint32(&c.WorkersIdle)
+	x.uint32(&c.WorkersMax)
+	x.uint32(&c.ReqDelayed)
+	x.uint32(&c.ReqDropped)
+}
+
+// EncodeXDR writes the XDR representation of AppWorkersCounter to w
+func (c AppWorkersCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(c.WorkersActive)
+	x.uint32(c.WorkersIdle)
+	x.uint32(c.WorkersMax)
+	x.uint32(c.ReqDelayed)
+	x.uint32(c.ReqDropped)
+	return x.err
+}
+
+// DecodeXDR decodes BroadcomDeviceBuffersCounter from r and returns the number of bytes read
+func (c *BroadcomDeviceBuffersCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *BroadcomDeviceBuffersCounter) decodeXDR(x *xdrReader) {
+	x.int32(&c.UnicastPc)
+	x.int32(&c.MulticastPc)
+}
+
+// EncodeXDR writes the XDR representation of BroadcomDeviceBuffersCounter to w
+func (c BroadcomDeviceBuffersCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.int32(c.UnicastPc)
+	x.int32(c.MulticastPc)
+	return x.err
+}
+
+// DecodeXDR decodes BroadcomPortBuffersCounter from r and returns the number of bytes read
+func (c *BroadcomPortBuffersCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *BroadcomPortBuffersCounter) decodeXDR(x *xdrReader) {
+	x.int32(&c.IngressUnicastPc)
+	x.int32(&c.IngressMulticastPc)
+	x.int32(&c.EgressUnicastPc)
+	x.int32(&c.EgressMulticastPc)
+	x.uint32(&c.EgressQueueUnicastLen)
+	x.int32s(&c.EgressQueueUnicastPc, x.length("BroadcomPortBuffersCounter.EgressQueueUnicastPc", uint64(c.EgressQueueUnicastLen), MaximumRecordLength))
+	x.uint32(&c.EgressQueueMulticastLen)
+	x.int32s(&c.EgressQueueMulticastPc, x.length("BroadcomPortBuffersCounter.EgressQueueMulticastPc", uint64(c.EgressQueueMulticastLen), MaximumRecordLength))
+}
+
+// EncodeXDR writes the XDR representation of BroadcomPortBuffersCounter to w
+func (c BroadcomPortBuffersCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.int32(c.IngressUnicastPc)
+	x.int32(c.IngressMulticastPc)
+	x.int32(c.EgressUnicastPc)
+	x.int32(c.EgressMulticastPc)
+	x.uint32(uint32(len(c.EgressQueueUnicastPc)))
+	x.length("BroadcomPortBuffersCounter.EgressQueueUnicastPc", len(c.EgressQueueUnicastPc), MaximumRecordLength)
+	x.int32s(c.EgressQueueUnicastPc)
+	x.uint32(uint32(len(c.EgressQueueMulticastPc)))
+	x.length("BroadcomPortBuffersCounter.EgressQueueMulticastPc", len(c.EgressQueueMulticastPc), MaximumRecordLength)
+	x.int32s(c.EgressQueueMulticastPc)
+	return x.err
+}
+
+// DecodeXDR decodes BroadcomTablesCounter from r and returns the number of bytes read
+func (c *BroadcomTablesCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *BroadcomTablesCounter) decodeXDR(x *xdrReader) {
+	x.uint32(&c.HostEntries)
+	x.uint32(&c.HostEntriesMax)
+	x.uint32(&c.IPv4Entries)
+	x.uint32(&c.IPv4EntriesMax)
+	x.uint32(&c.IPv6Entries)
+	x.uint32(&c.IPv6EntriesMax)
+	x.uint32(&c.IPv4IPv6Entries)
+	x.uint32(&c.IPv4IPv6EntriesMax)
+	x.uint32(&c.LongIPv6Entries)
+	x.uint32(&c.LongIPv6EntriesMax)
+	x.uint32(&c.TotalRoutes)
+	x.uint32(&c.TotalRoutesMax)
+	x.uint32(&c.ECMPNextHops)
+	x.uint32(&c.ECMPNextHopsMax)
+	x.uint32(&c.MACEntries)
+	x.uint32(&c.MACEntriesMax)
+	x.uint32(&c.IPv4Neighbors)
+	x.uint32(&c.IPv6Neighbors)
+	x.uint32(&c.IPv4Routes)
+	x.uint32(&c.IPv6Routes)
+	x.uint32(&c.ACLIngressEntries)
+	x.uint32(&c.ACLIngressEntriesMax)
+	x.uint32(&c.ACLIngressCounters)
+	x.uint32(&c.ACLIngressCountersMax)
+	x.uint32(&c.ACLIngressMeters)
+	x.uint32(&c.ACLIngressMetersMax)
+	x.uint32(&c.ACLIngressSlices)
+	x.uint32(&c.ACLIngressSlicesMax)
+	x.uint32(&c.ACLEgressEntries)
+	x.uint32(&c.ACLEgressEntriesMax)
+	x.uint32(&c.ACLEgressCounters)
+	x.uint32(&c.ACLEgressCountersMax)
+	x.uint32(&c.ACLEgressMeters)
+	x.uint32(&c.ACLEgressMetersMax)
+	x.uint32(&c.ACLEgressSlices)
+	x.uint32(&c.ACLEgressSlicesMax)
+}
+
+// EncodeXDR writes the XDR representation of BroadcomTablesCounter to w
+func (c BroadcomTablesCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(c.HostEntries)
+	x.uint32(c.HostEntriesMax)
+	x.uint32(c.IPv4Entries)
+	x.uint32(c.IPv4EntriesMax)
+	x.uint32(c.IPv6Entries)
+	x.uint32(c.IPv6EntriesMax)
+	x.uint32(c.IPv4IPv6Entries)
+	x.uint32(c.IPv4IPv6EntriesMax)
+	x.uint32(c.LongIPv6Entries)
+	x.uint32(c.LongIPv6EntriesMax)
+	x.uint32(c.TotalRoutes)
+	x.uint32(c.TotalRoutesMax)
+	x.uint32(c.ECMPNextHops)
+	x.uint32(c.ECMPNextHopsMax)
+	x.uint32(c.MACEntries)
+	x.uint32(c.MACEntriesMax)
+	x.uint32(c.IPv4Neighbors)
+	x.uint32(c.IPv6Neighbors)
+	x.uint32(c.IPv4Routes)
+	x.uint32(c.IPv6Routes)
+	x.uint32(c.ACLIngressEntries)
+	x.uint32(c.ACLIngressEntriesMax)
+	x.uint32(c.ACLIngressCounters)
+	x.uint32(c.ACLIngressCountersMax)
+	x.uint32(c.ACLIngressMeters)
+	x.uint32(c.ACLIngressMetersMax)
+	x.uint32(c.ACLIngressSlices)
+	x.uint32(c.ACLIngressSlicesMax)
+	x.uint32(c.ACLEgressEntries)
+	x.uint32(c.ACLEgressEntriesMax)
+	x.uint32(c.ACLEgressCounters)
+	x.uint32(c.ACLEgressCountersMax)
+	x.uint32(c.ACLEgressMeters)
+	x.uint32(c.ACLEgressMetersMax)
+	x.uint32(c.ACLEgressSlices)
+	x.uint32(c.ACLEgressSlicesMax)
+	return x.err
+}
+
+// DecodeXDR decodes NVIDIAGPUCounter from r and returns the number of bytes read
+func (c *NVIDIAGPUCounter) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	c.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (c *NVIDIAGPUCounter) decodeXDR(x *xdrReader) {
+	x.uint32(&c.DeviceCount)
+	x.uint32(&c.Processes)
+	x.uint32(&c.GPUTime)
+	x.uint32(&c.MemTime)
+	x.uint64(&c.MemTotal)
+	x.uint64(&c.MemFree)
+	x.uint32(&c.ECCErrors)
+	x.uint32(&c.Energy)
+	x.uint32(&c.Temperature)
+	x.uint32(&c.FanSpeed)
+}
+
+// EncodeXDR writes the XDR representation of NVIDIAGPUCounter to w
+func (c NVIDIAGPUCounter) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint32(c.DeviceCount)
+	x.uint32(c.Processes)
+	x.uint32(c.GPUTime)
+	x.uint32(c.MemTime)
+	x.uint64(c.MemTotal)
+	x.uint64(c.MemFree)
+	x.uint32(c.ECCErrors)
+	x.uint32(c.Energy)
+	x.uint32(c.Temperature)
+	x.uint32(c.FanSpeed)
+	return x.err
+}
+
+// DecodeXDR decodes EthernetHeader from r and returns the number of bytes read
+func (f *EthernetHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *EthernetHeader) decodeXDR(x *xdrReader) {
+	x.hardwareAddr(&f.DstMac)
+	x.hardwareAddr(&f.SrcMac)
+}
+
+// EncodeXDR writes the XDR representation of EthernetHeader to w
+func (f EthernetHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.write(f.DstMac)
+	x.write(f.SrcMac)
+	return x.err
+}
+
+// DecodeXDR decodes VLANTag from r and returns the number of bytes read
+func (f *VLANTag) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *VLANTag) decodeXDR(x *xdrReader) {
+	x.uint16(&f.TCI)
+}
+
+// EncodeXDR writes the XDR representation of VLANTag to w
+func (f VLANTag) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint16(f.TCI)
+	return x.err
+}
+
+// DecodeXDR decodes LLCHeader from r and returns the number of bytes read
+func (f *LLCHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *LLCHeader) decodeXDR(x *xdrReader) {
+	x.uint8(&f.DSAP)
+	x.uint8(&f.SSAP)
+}
+
+// EncodeXDR writes the XDR representation of LLCHeader to w
+func (f LLCHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint8(f.DSAP)
+	x.uint8(f.SSAP)
+	return x.err
+}
+
+// DecodeXDR decodes IPXHeader from r and returns the number of bytes read
+func (f *IPXHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *IPXHeader) decodeXDR(x *xdrReader) {
+	x.uint16(&f.Checksum)
+	x.uint16(&f.Length)
+	x.uint8(&f.TransportControl)
+	x.uint8(&f.PacketType)
+	x.uint32(&f.DstNetwork)
+	x.hardwareAddr(&f.DstNode)
+	x.uint16(&f.DstSocket)
+	x.uint32(&f.SrcNetwork)
+	x.hardwareAddr(&f.SrcNode)
+	x.uint16(&f.SrcSocket)
+}
+
+// EncodeXDR writes the XDR representation of IPXHeader to w
+func (f IPXHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint16(f.Checksum)
+	x.uint16(f.Length)
+	x.uint8(f.TransportControl)
+	x.uint8(f.PacketType)
+	x.uint32(f.DstNetwork)
+	x.write(f.DstNode)
+	x.uint16(f.DstSocket)
+	x.uint32(f.SrcNetwork)
+	x.write(f.SrcNode)
+	x.uint16(f.SrcSocket)
+	return x.err
+}
+
+// DecodeXDR decodes ARPHeader from r and returns the number of bytes read
+func (f *ARPHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ARPHeader) decodeXDR(x *xdrReader) {
+	x.uint16(&f.HardwareType)
+	x.uint16(&f.ProtocolType)
+	x.uint8(&f.HardwareLen)
+	x.uint8(&f.ProtocolLen)
+	x.uint16(&f.Operation)
+}
+
+// EncodeXDR writes the XDR representation of ARPHeader to w
+func (f ARPHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint16(f.HardwareType)
+	x.uint16(f.ProtocolType)
+	x.uint8(f.HardwareLen)
+	x.uint8(f.ProtocolLen)
+	x.uint16(f.Operation)
+	return x.err
+}
+
+// DecodeXDR decodes TokenRingHeader from r and returns the number of bytes read
+func (f *TokenRingHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *TokenRingHeader) decodeXDR(x *xdrReader) {
+	x.uint8(&f.AccessControl)
+	x.uint8(&f.FrameControl)
+	x.hardwareAddr(&f.DstMac)
+	x.hardwareAddr(&f.SrcMac)
+}
+
+// EncodeXDR writes the XDR representation of TokenRingHeader to w
+func (f TokenRingHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint8(f.AccessControl)
+	x.uint8(f.FrameControl)
+	x.write(f.DstMac)
+	x.write(f.SrcMac)
+	return x.err
+}
+
+// DecodeXDR decodes FDDIHeader from r and returns the number of bytes read
+func (f *FDDIHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *FDDIHeader) decodeXDR(x *xdrReader) {
+	x.uint8(&f.FrameControl)
+	x.hardwareAddr(&f.DstMac)
+	x.hardwareAddr(&f.SrcMac)
+}
+
+// EncodeXDR writes the XDR representation of FDDIHeader to w
+func (f FDDIHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint8(f.FrameControl)
+	x.write(f.DstMac)
+	x.write(f.SrcMac)
+	return x.err
+}
+
+// DecodeXDR decodes FrameRelayHeader from r and returns the number of bytes read
+func (f *FrameRelayHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *FrameRelayHeader) decodeXDR(x *xdrReader) {
+	x.uint16(&f.Address)
+}
+
+// EncodeXDR writes the XDR representation of FrameRelayHeader to w
+func (f FrameRelayHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint16(f.Address)
+	return x.err
+}
+
+// DecodeXDR decodes IPv4Header from r and returns the number of bytes read
+func (f *IPv4Header) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *IPv4Header) decodeXDR(x *xdrReader) {
+	x.uint8(&f.VersionAndLen)
+	x.uint8(&f.Tos)
+	x.uint16(&f.TotLen)
+	x.uint16(&f.ID)
+	x.uint16(&f.FragOff)
+	x.uint8(&f.TTL)
+	x.uint8(&f.Protocol)
+	x.uint16(&f.Check)
+	x.ip(&f.SrcAddr, 4)
+	x.ip(&f.DstAddr, 4)
+}
+
+// EncodeXDR writes the XDR representation of IPv4Header to w
+func (f IPv4Header) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint8(f.VersionAndLen)
+	x.uint8(f.Tos)
+	x.uint16(f.TotLen)
+	x.uint16(f.ID)
+	x.uint16(f.FragOff)
+	x.uint8(f.TTL)
+	x.uint8(f.Protocol)
+	x.uint16(f.Check)
+	x.ip(f.SrcAddr, 4)
+	x.ip(f.DstAddr, 4)
+	return x.err
+}
+
+// DecodeXDR decodes IPv6Header from r and returns the number of bytes read
+func (f *IPv6Header) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *IPv6Header) decodeXDR(x *xdrReader) {
+	x.uint8(&f.VersionAndPriority)
+	x.uint8(&f.Label1)
+	x.uint8(&f.Label2)
+	x.uint8(&f.Label3)
+	x.uint16(&f.PayloadLength)
+	x.uint8(&f.NextHeader)
+	x.uint8(&f.TTL)
+	x.ip(&f.SrcAddr, 16)
+	x.ip(&f.DstAddr, 16)
+}
+
+// EncodeXDR writes the XDR representation of IPv6Header to w
+func (f IPv6Header) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint8(f.VersionAndPriority)
+	x.uint8(f.Label1)
+	x.uint8(f.Label2)
+	x.uint8(f.Label3)
+	x.uint16(f.PayloadLength)
+	x.uint8(f.NextHeader)
+	x.uint8(f.TTL)
+	x.ip(f.SrcAddr, 16)
+	x.ip(f.DstAddr, 16)
+	return x.err
+}
+
+// DecodeXDR decodes GREHeader from r and returns the number of bytes read
+func (f *GREHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *GREHeader) decodeXDR(x *xdrReader) {
+	x.uint16(&f.FlagsVersion)
+	x.uint16(&f.ProtocolType)
+}
+
+// EncodeXDR writes the XDR representation of GREHeader to w
+func (f GREHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint16(f.FlagsVersion)
+	x.uint16(f.ProtocolType)
+	return x.err
+}
+
+// DecodeXDR decodes ICMPHeader from r and returns the number of bytes read
+func (f *ICMPHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *ICMPHeader) decodeXDR(x *xdrReader) {
+	x.uint8(&f.Type)
+	x.uint8(&f.Code)
+	x.uint16(&f.Checksum)
+}
+
+// EncodeXDR writes the XDR representation of ICMPHeader to w
+func (f ICMPHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint8(f.Type)
+	x.uint8(f.Code)
+	x.uint16(f.Checksum)
+	return x.err
+}
+
+// DecodeXDR decodes TCPHeader from r and returns the number of bytes read
+func (f *TCPHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *TCPHeader) decodeXDR(x *xdrReader) {
+	x.uint16(&f.SrcPort)
+	x.uint16(&f.DstPort)
+	x.uint32(&f.Seq)
+	x.uint32(&f.Ack)
+	x.uint8(&f.OffsetReserved)
+	x.uint8(&f.Flags)
+	x.uint16(&f.Window)
+	x.uint16(&f.Checksum)
+	x.uint16(&f.Urgent)
+}
+
+// EncodeXDR writes the XDR representation of TCPHeader to w
+func (f TCPHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint16(f.SrcPort)
+	x.uint16(f.DstPort)
+	x.uint32(f.Seq)
+	x.uint32(f.Ack)
+	x.uint8(f.OffsetReserved)
+	x.uint8(f.Flags)
+	x.uint16(f.Window)
+	x.uint16(f.Checksum)
+	x.uint16(f.Urgent)
+	return x.err
+}
+
+// DecodeXDR decodes UDPHeader from r and returns the number of bytes read
+func (f *UDPHeader) DecodeXDR(r io.Reader) (int, error) {
+	x := xdrReader{r: r}
+	f.decodeXDR(&x)
+	return x.n, x.err
+}
+
+func (f *UDPHeader) decodeXDR(x *xdrReader) {
+	x.uint16(&f.SrcPort)
+	x.uint16(&f.DstPort)
+	x.uint16(&f.Length)
+	x.uint16(&f.Checksum)
+}
+
+// EncodeXDR writes the XDR representation of UDPHeader to w
+func (f UDPHeader) EncodeXDR(w io.Writer) error {
+	x := &xdrWriter{w: w}
+	x.uint16(f.SrcPort)
+	x.uint16(f.DstPort)
+	x.uint16(f.Length)
+	x.uint16(f.Checksum)
+	return x.err
+}
+
+// decodeFlowXDR decodes the flow records of the given type which have a generated decoder from r.
+// It returns false for all other record types.
+func decodeFlowXDR(r io.Reader, recordType uint32) (Record, bool, error) {
+	switch recordType {
+	case TypeEthernetFrameFlowRecord:
+		rec := EthernetFrameFlow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeExtendedSwitchFlowRecord:
+		rec := ExtendedSwitchFlow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeExtendedRouterFlowRecord:
+		rec := ExtendedRouterFlow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeExtendedGatewayFlowRecord:
+		rec := ExtendedGatewayFlow{}
+		_, err := rec.DecodeXDR(r)
+		rec.PostDecode()
+		return rec, true, err
+	case TypeTransactionFlowRecord:
+		rec := TransactionFlow{}
+		_, err := rec.DecodeXDR(r)
+		rec.PostDecode()
+		return rec, true, err
+	case TypeExtendedNFSStorageTransactionFlowRecord:
+		rec := ExtendedNFSStorageTransactionFlow{}
+		_, err := rec.DecodeXDR(r)
+		rec.PostDecode()
+		return rec, true, err
+	case TypeExtendedSCSIStorageTransactionFlowRecord:
+		rec := ExtendedSCSIStorageTransactionFlow{}
+		_, err := rec.DecodeXDR(r)
+		rec.PostDecode()
+		return rec, true, err
+	case TypeExtendedSocketIPv4FlowRecord:
+		rec := ExtendedSocketIPv4Flow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeExtendedSocketIPv6FlowRecord:
+		rec := ExtendedSocketIPv6Flow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeExtendedProxySocketIPv4FlowRecord:
+		rec := ExtendedProxySocketIPv4Flow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeExtendedProxySocketIPv6FlowRecord:
+		rec := ExtendedProxySocketIPv6Flow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeMemcacheOperationFlowRecord:
+		rec := MemcacheOperationFlow{}
+		_, err := rec.DecodeXDR(r)
+		rec.PostDecode()
+		return rec, true, err
+	case TypeAppOperationFlowRecord:
+		rec := AppOperationFlow{}
+		_, err := rec.DecodeXDR(r)
+		rec.PostDecode()
+		return rec, true, err
+	case TypeAppParentContextFlowRecord:
+		rec := AppParentContextFlow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeAppInitiatorFlowRecord:
+		rec := AppInitiatorFlow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeAppTargetFlowRecord:
+		rec := AppTargetFlow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeHTTPRequestFlowRecord:
+		rec := HTTPRequestFlow{}
+		_, err := rec.DecodeXDR(r)
+		rec.PostDecode()
+		return rec, true, err
+	case TypeHTTPExtendedProxyFlowRecord:
+		rec := ExtendedProxyRequestFlow{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeExtendedNavTimingFlowRecord:
+		rec := ExtendedNavTimingFlow{}
+		_, err := rec.DecodeXDR(r)
+		rec.PostDecode()
+		return rec, true, err
+	}
+
+	return nil, false, nil
+}
+
+// decodeCounterXDR decodes the counter records of the given type which have a generated decoder from r.
+// It returns false for all other record types.
+func decodeCounterXDR(r io.Reader, recordType uint32) (Record, bool, error) {
+	switch recordType {
+	case TypeJMXRuntimeCounterRecord:
+		rec := JMXRuntimeCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeJMXStatisticsCounterRecord:
+		rec := JMXStatisticsCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeHTTPCounterRecord:
+		rec := HTTPCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeAppOperationsCounterRecord:
+		rec := AppOperationsCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeAppResourcesCounterRecord:
+		rec := AppResourcesCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeMemcacheCounterRecord:
+		rec := MemcacheCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeAppWorkersCounterRecord:
+		rec := AppWorkersCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeBroadcomDeviceBuffersCounterRecord:
+		rec := BroadcomDeviceBuffersCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeBroadcomPortBuffersCounterRecord:
+		rec := BroadcomPortBuffersCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeBroadcomTablesCounterRecord:
+		rec := BroadcomTablesCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	case TypeNVIDIAGPUCounterRecord:
+		rec := NVIDIAGPUCounter{}
+		_, err := rec.DecodeXDR(r)
+		return rec, true, err
+	}
+
+	return nil, false, nil
+}
+
+// decodeFlowXDRBytes decodes the flow records of the given type which have a generated decoder from b in place
+// and returns the number of bytes read. It returns false for all other record types.
+func decodeFlowXDRBytes(b []byte, recordType uint32) (Record, int, bool, error) {
+	x := xdrReader{b: b}
+
+	switch recordType {
+	case TypeEthernetFrameFlowRecord:
+		rec := ethernetFrameFlowPool.Get().(*EthernetFrameFlow)
+		*rec = EthernetFrameFlow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeExtendedSwitchFlowRecord:
+		rec := extendedSwitchFlowPool.Get().(*ExtendedSwitchFlow)
+		*rec = ExtendedSwitchFlow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeExtendedRouterFlowRecord:
+		rec := extendedRouterFlowPool.Get().(*ExtendedRouterFlow)
+		*rec = ExtendedRouterFlow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeExtendedGatewayFlowRecord:
+		rec := extendedGatewayFlowPool.Get().(*ExtendedGatewayFlow)
+		*rec = ExtendedGatewayFlow{}
+		rec.decodeXDR(&x)
+		rec.PostDecode()
+		return rec, x.n, true, x.err
+	case TypeTransactionFlowRecord:
+		rec := transactionFlowPool.Get().(*TransactionFlow)
+		*rec = TransactionFlow{}
+		rec.decodeXDR(&x)
+		rec.PostDecode()
+		return rec, x.n, true, x.err
+	case TypeExtendedNFSStorageTransactionFlowRecord:
+		rec := extendedNFSStorageTransactionFlowPool.Get().(*ExtendedNFSStorageTransactionFlow)
+		*rec = ExtendedNFSStorageTransactionFlow{}
+		rec.decodeXDR(&x)
+		rec.PostDecode()
+		return rec, x.n, true, x.err
+	case TypeExtendedSCSIStorageTransactionFlowRecord:
+		rec := extendedSCSIStorageTransactionFlowPool.Get().(*ExtendedSCSIStorageTransactionFlow)
+		*rec = ExtendedSCSIStorageTransactionFlow{}
+		rec.decodeXDR(&x)
+		rec.PostDecode()
+		return rec, x.n, true, x.err
+	case TypeExtendedSocketIPv4FlowRecord:
+		rec := extendedSocketIPv4FlowPool.Get().(*ExtendedSocketIPv4Flow)
+		*rec = ExtendedSocketIPv4Flow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeExtendedSocketIPv6FlowRecord:
+		rec := extendedSocketIPv6FlowPool.Get().(*ExtendedSocketIPv6Flow)
+		*rec = ExtendedSocketIPv6Flow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeExtendedProxySocketIPv4FlowRecord:
+		rec := extendedProxySocketIPv4FlowPool.Get().(*ExtendedProxySocketIPv4Flow)
+		*rec = ExtendedProxySocketIPv4Flow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeExtendedProxySocketIPv6FlowRecord:
+		rec := extendedProxySocketIPv6FlowPool.Get().(*ExtendedProxySocketIPv6Flow)
+		*rec = ExtendedProxySocketIPv6Flow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeMemcacheOperationFlowRecord:
+		rec := memcacheOperationFlowPool.Get().(*MemcacheOperationFlow)
+		*rec = MemcacheOperationFlow{}
+		rec.decodeXDR(&x)
+		rec.PostDecode()
+		return rec, x.n, true, x.err
+	case TypeAppOperationFlowRecord:
+		rec := appOperationFlowPool.Get().(*AppOperationFlow)
+		*rec = AppOperationFlow{}
+		rec.decodeXDR(&x)
+		rec.PostDecode()
+		return rec, x.n, true, x.err
+	case TypeAppParentContextFlowRecord:
+		rec := appParentContextFlowPool.Get().(*AppParentContextFlow)
+		*rec = AppParentContextFlow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeAppInitiatorFlowRecord:
+		rec := appInitiatorFlowPool.Get().(*AppInitiatorFlow)
+		*rec = AppInitiatorFlow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeAppTargetFlowRecord:
+		rec := appTargetFlowPool.Get().(*AppTargetFlow)
+		*rec = AppTargetFlow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeHTTPRequestFlowRecord:
+		rec := httpRequestFlowPool.Get().(*HTTPRequestFlow)
+		*rec = HTTPRequestFlow{}
+		rec.decodeXDR(&x)
+		rec.PostDecode()
+		return rec, x.n, true, x.err
+	case TypeHTTPExtendedProxyFlowRecord:
+		rec := extendedProxyRequestFlowPool.Get().(*ExtendedProxyRequestFlow)
+		*rec = ExtendedProxyRequestFlow{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeExtendedNavTimingFlowRecord:
+		rec := extendedNavTimingFlowPool.Get().(*ExtendedNavTimingFlow)
+		*rec = ExtendedNavTimingFlow{}
+		rec.decodeXDR(&x)
+		rec.PostDecode()
+		return rec, x.n, true, x.err
+	}
+
+	return nil, 0, false, nil
+}
+
+// decodeCounterXDRBytes decodes the counter records of the given type which have a generated decoder from b in place
+// and returns the number of bytes read. It returns false for all other record types.
+func decodeCounterXDRBytes(b []byte, recordType uint32) (Record, int, bool, error) {
+	x := xdrReader{b: b}
+
+	switch recordType {
+	case TypeJMXRuntimeCounterRecord:
+		rec := jmxRuntimeCounterPool.Get().(*JMXRuntimeCounter)
+		*rec = JMXRuntimeCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeJMXStatisticsCounterRecord:
+		rec := jmxStatisticsCounterPool.Get().(*JMXStatisticsCounter)
+		*rec = JMXStatisticsCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeHTTPCounterRecord:
+		rec := httpCounterPool.Get().(*HTTPCounter)
+		*rec = HTTPCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeAppOperationsCounterRecord:
+		rec := appOperationsCounterPool.Get().(*AppOperationsCounter)
+		*rec = AppOperationsCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeAppResourcesCounterRecord:
+		rec := appResourcesCounterPool.Get().(*AppResourcesCounter)
+		*rec = AppResourcesCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeMemcacheCounterRecord:
+		rec := memcacheCounterPool.Get().(*MemcacheCounter)
+		*rec = MemcacheCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeAppWorkersCounterRecord:
+		rec := appWorkersCounterPool.Get().(*AppWorkersCounter)
+		*rec = AppWorkersCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeBroadcomDeviceBuffersCounterRecord:
+		rec := broadcomDeviceBuffersCounterPool.Get().(*BroadcomDeviceBuffersCounter)
+		*rec = BroadcomDeviceBuffersCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeBroadcomPortBuffersCounterRecord:
+		rec := broadcomPortBuffersCounterPool.Get().(*BroadcomPortBuffersCounter)
+		*rec = BroadcomPortBuffersCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeBroadcomTablesCounterRecord:
+		rec := broadcomTablesCounterPool.Get().(*BroadcomTablesCounter)
+		*rec = BroadcomTablesCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	case TypeNVIDIAGPUCounterRecord:
+		rec := nvidiagpuCounterPool.Get().(*NVIDIAGPUCounter)
+		*rec = NVIDIAGPUCounter{}
+		rec.decodeXDR(&x)
+		return rec, x.n, true, x.err
+	}
+
+	return nil, 0, false, nil
+}
+
+// Records decoded in place are taken from these pools and put back by releaseXDRRecord
+var (
+	ethernetFrameFlowPool                  = sync.Pool{New: func() interface{} { return &EthernetFrameFlow{} }}
+	extendedSwitchFlowPool                 = sync.Pool{New: func() interface{} { return &ExtendedSwitchFlow{} }}
+	extendedRouterFlowPool                 = sync.Pool{New: func() interface{} { return &ExtendedRouterFlow{} }}
+	extendedGatewayFlowPool                = sync.Pool{New: func() interface{} { return &ExtendedGatewayFlow{} }}
+	transactionFlowPool                    = sync.Pool{New: func() interface{} { return &TransactionFlow{} }}
+	extendedNFSStorageTransactionFlowPool  = sync.Pool{New: func() interface{} { return &ExtendedNFSStorageTransactionFlow{} }}
+	extendedSCSIStorageTransactionFlowPool = sync.Pool{New: func() interface{} { return &ExtendedSCSIStorageTransactionFlow{} }}
+	extendedSocketIPv4FlowPool             = sync.Pool{New: func() interface{} { return &ExtendedSocketIPv4Flow{} }}
+	extendedSocketIPv6FlowPool             = sync.Pool{New: func() interface{} { return &ExtendedSocketIPv6Flow{} }}
+	extendedProxySocketIPv4FlowPool        = sync.Pool{New: func() interface{} { return &ExtendedProxySocketIPv4Flow{} }}
+	extendedProxySocketIPv6FlowPool        = sync.Pool{New: func() interface{} { return &ExtendedProxySocketIPv6Flow{} }}
+	memcacheOperationFlowPool              = sync.Pool{New: func() interface{} { return &MemcacheOperationFlow{} }}
+	appOperationFlowPool                   = sync.Pool{New: func() interface{} { return &AppOperationFlow{} }}
+	appParentContextFlowPool               = sync.Pool{New: func() interface{} { return &AppParentContextFlow{} }}
+	appInitiatorFlowPool                   = sync.Pool{New: func() interface{} { return &AppInitiatorFlow{} }}
+	appTargetFlowPool                      = sync.Pool{New: func() interface{} { return &AppTargetFlow{} }}
+	httpRequestFlowPool                    = sync.Pool{New: func() interface{} { return &HTTPRequestFlow{} }}
+	extendedProxyRequestFlowPool           = sync.Pool{New: func() interface{} { return &ExtendedProxyRequestFlow{} }}
+	extendedNavTimingFlowPool              = sync.Pool{New: func() interface{} { return &ExtendedNavTimingFlow{} }}
+	jmxRuntimeCounterPool                  = sync.Pool{New: func() interface{} { return &JMXRuntimeCounter{} }}
+	jmxStatisticsCounterPool               = sync.Pool{New: func() interface{} { return &JMXStatisticsCounter{} }}
+	httpCounterPool                        = sync.Pool{New: func() interface{} { return &HTTPCounter{} }}
+	appOperationsCounterPool               = sync.Pool{New: func() interface{} { return &AppOperationsCounter{} }}
+	appResourcesCounterPool                = sync.Pool{New: func() interface{} { return &AppResourcesCounter{} }}
+	memcacheCounterPool                    = sync.Pool{New: func() interface{} { return &MemcacheCounter{} }}
+	appWorkersCounterPool                  = sync.Pool{New: func() interface{} { return &AppWorkersCounter{} }}
+	broadcomDeviceBuffersCounterPool       = sync.Pool{New: func() interface{} { return &BroadcomDeviceBuffersCounter{} }}
+	broadcomPortBuffersCounterPool         = sync.Pool{New: func() interface{} { return &BroadcomPortBuffersCounter{} }}
+	broadcomTablesCounterPool              = sync.Pool{New: func() interface{} { return &BroadcomTablesCounter{} }}
+	nvidiagpuCounterPool                   = sync.Pool{New: func() interface{} { return &NVIDIAGPUCounter{} }}
+)
+
+// releaseXDRRecord puts a record decoded in place back into its pool.
+// It returns false for all other records.
+func releaseXDRRecord(rec Record) bool {
+	switch rec := rec.(type) {
+	case *EthernetFrameFlow:
+		ethernetFrameFlowPool.Put(rec)
+	case *ExtendedSwitchFlow:
+		extendedSwitchFlowPool.Put(rec)
+	case *ExtendedRouterFlow:
+		extendedRouterFlowPool.Put(rec)
+	case *ExtendedGatewayFlow:
+		extendedGatewayFlowPool.Put(rec)
+	case *TransactionFlow:
+		transactionFlowPool.Put(rec)
+	case *ExtendedNFSStorageTransactionFlow:
+		extendedNFSStorageTransactionFlowPool.Put(rec)
+	case *ExtendedSCSIStorageTransactionFlow:
+		extendedSCSIStorageTransactionFlowPool.Put(rec)
+	case *ExtendedSocketIPv4Flow:
+		extendedSocketIPv4FlowPool.Put(rec)
+	case *ExtendedSocketIPv6Flow:
+		extendedSocketIPv6FlowPool.Put(rec)
+	case *ExtendedProxySocketIPv4Flow:
+		extendedProxySocketIPv4FlowPool.Put(rec)
+	case *ExtendedProxySocketIPv6Flow:
+		extendedProxySocketIPv6FlowPool.Put(rec)
+	case *MemcacheOperationFlow:
+		memcacheOperationFlowPool.Put(rec)
+	case *AppOperationFlow:
+		appOperationFlowPool.Put(rec)
+	case *AppParentContextFlow:
+		appParentContextFlowPool.Put(rec)
+	case *AppInitiatorFlow:
+		appInitiatorFlowPool.Put(rec)
+	case *AppTargetFlow:
+		appTargetFlowPool.Put(rec)
+	case *HTTPRequestFlow:
+		httpRequestFlowPool.Put(rec)
+	case *ExtendedProxyRequestFlow:
+		extendedProxyRequestFlowPool.Put(rec)
+	case *ExtendedNavTimingFlow:
+		extendedNavTimingFlowPool.Put(rec)
+	case *JMXRuntimeCounter:
+		jmxRuntimeCounterPool.Put(rec)
+	case *JMXStatisticsCounter:
+		jmxStatisticsCounterPool.Put(rec)
+	case *HTTPCounter:
+		httpCounterPool.Put(rec)
+	case *AppOperationsCounter:
+		appOperationsCounterPool.Put(rec)
+	case *AppResourcesCounter:
+		appResourcesCounterPool.Put(rec)
+	case *MemcacheCounter:
+		memcacheCounterPool.Put(rec)
+	case *AppWorkersCounter:
+		appWorkersCounterPool.Put(rec)
+	case *BroadcomDeviceBuffersCounter:
+		broadcomDeviceBuffersCounterPool.Put(rec)
+	case *BroadcomPortBuffersCounter:
+		broadcomPortBuffersCounterPool.Put(rec)
+	case *BroadcomTablesCounter:
+		broadcomTablesCounterPool.Put(rec)
+	case *NVIDIAGPUCounter:
+		nvidiagpuCounterPool.Put(rec)
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,412 @@
+package records
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"net"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// testFill sets the encoded fields of the struct v to random values. Slices get a random number of elements
+// with their lengthLookUp field set accordingly, IP addresses a random version and strings random letters.
+// Fixed length arrays are left zero.
+func testFill(rnd *rand.Rand, v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := v.Field(i)
+
+		if sf.Tag.Get("ignoreOnMarshal") == "true" {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			// Lookup fields are overwritten by their slice or IP address
+			field.SetUint(rnd.Uint64())
+		case reflect.Int32, reflect.Int64:
+			field.SetInt(rnd.Int63() - rnd.Int63())
+		case reflect.Struct:
+			testFill(rnd, field)
+		case reflect.Slice:
+			if field.Type() == reflect.TypeOf(net.IP{}) {
+				ip := make(net.IP, 16)
+				rnd.Read(ip)
+
+				switch sf.Tag.Get("ipVersion") {
+				case "4":
+					ip = ip[:4]
+				case "6":
+				default:
+					lookup := v.FieldByName(sf.Tag.Get("ipVersionLookUp"))
+					lookup.SetUint(uint64(1 + rnd.Intn(2)))
+					if lookup.Uint() == 1 {
+						ip = ip[:4]
+					}
+				}
+				field.SetBytes(ip)
+				continue
+			}
+
+			if field.Type() == reflect.TypeOf(HardwareAddr{}) {
+				mac := make([]byte, 6)
+				rnd.Read(mac)
+				field.SetBytes(mac)
+				continue
+			}
+
+			n := rnd.Intn(6)
+			v.FieldByName(sf.Tag.Get("lengthLookUp")).SetUint(uint64(n))
+			if n == 0 {
+				continue
+			}
+
+			field.Set(reflect.MakeSlice(field.Type(), n, n))
+			for x := 0; x < n; x++ {
+				elem := field.Index(x)
+				switch elem.Kind() {
+				case reflect.Uint8, reflect.Uint32:
+					elem.SetUint(rnd.Uint64())
+					// Strings are written to JSON, which holds only valid UTF-8
+					if field.Type() == reflect.TypeOf(XDRString{}) {
+						elem.SetUint(uint64('a' + rnd.Intn(26)))
+					}
+				case reflect.Int32:
+					elem.SetInt(rnd.Int63())
+				case reflect.Struct:
+					testFill(rnd, elem)
+				}
+			}
+		}
+	}
+}
+
+// testGeneratedRecords returns a zero value of each registered record with a generated codec
+func testGeneratedRecords(t *testing.T) []interface{} {
+	var recs []interface{}
+
+	for _, registry := range []map[uint32]interface{}{flowRecordTypes, counterRecordTypes} {
+		// In the order of the record types, so a failure can be reproduced with the same random values
+		var types []int
+		for recordType := range registry {
+			types = append(types, int(recordType))
+		}
+		sort.Ints(types)
+
+		for _, recordType := range types {
+			rec := registry[uint32(recordType)]
+			_, decoder := reflect.New(reflect.TypeOf(rec)).Interface().(XDRDecoder)
+			_, encoder := rec.(XDREncoder)
+
+			if decoder != encoder {
+				t.Errorf("%T has only one of DecodeXDR and EncodeXDR", rec)
+			}
+			if decoder {
+				recs = append(recs, rec)
+			}
+		}
+	}
+
+	if len(recs) == 0 {
+		t.Fatal("no records with a generated codec")
+	}
+
+	return recs
+}
+
+// testGeneratedHeaders are the headers of sampled packets with a generated codec
+var testGeneratedHeaders = []interface{}{
+	EthernetHeader{}, VLANTag{}, LLCHeader{}, IPXHeader{}, ARPHeader{}, TokenRingHeader{}, FDDIHeader{},
+	FrameRelayHeader{}, IPv4Header{}, IPv6Header{}, GREHeader{}, ICMPHeader{}, TCPHeader{}, UDPHeader{},
+}
+
+func TestGeneratedCodecsMatchReflection(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, rec := range append(testGeneratedRecords(t), testGeneratedHeaders...) {
+		for i := 0; i < 20; i++ {
+			value := reflect.New(reflect.TypeOf(rec)).Elem()
+			testFill(rnd, value)
+
+			generated, reflected := &bytes.Buffer{}, &bytes.Buffer{}
+			if err := value.Interface().(XDREncoder).EncodeXDR(generated); err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+			if err := encodeReflect(reflected, value.Interface()); err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+
+			if !bytes.Equal(generated.Bytes(), reflected.Bytes()) {
+				t.Fatalf("%T: expected the encoding\n%x\n, got\n%x", rec, reflected.Bytes(), generated.Bytes())
+			}
+
+			b := generated.Bytes()
+
+			decoded := reflect.New(reflect.TypeOf(rec))
+			n, err := decoded.Interface().(XDRDecoder).DecodeXDR(bytes.NewReader(b))
+			if err != nil || n != len(b) {
+				t.Fatalf("%T: decoded %d of %d bytes: %v", rec, n, len(b), err)
+			}
+
+			expected := reflect.New(reflect.TypeOf(rec))
+			if _, err := decodeReflect(bytes.NewReader(b), expected.Interface()); err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+
+			if !reflect.DeepEqual(decoded.Interface(), expected.Interface()) {
+				t.Fatalf("%T: expected\n%+#v\n, got\n%+#v", rec, expected.Interface(), decoded.Interface())
+			}
+
+			if !reflect.DeepEqual(decoded.Elem().Interface(), value.Interface()) {
+				t.Errorf("%T: expected the round trip to return\n%+#v\n, got\n%+#v", rec, value.Interface(), decoded.Interface())
+			}
+
+			// Both decoders fail on truncated data
+			for l := 0; l < len(b); l++ {
+				_, generatedErr := reflect.New(reflect.TypeOf(rec)).Interface().(XDRDecoder).DecodeXDR(bytes.NewReader(b[:l]))
+				_, reflectedErr := decodeReflect(bytes.NewReader(b[:l]), reflect.New(reflect.TypeOf(rec)).Interface())
+
+				if generatedErr == nil || reflectedErr == nil {
+					t.Fatalf("%T: expected errors decoding %d of %d bytes, got %v and %v", rec, l, len(b), generatedErr, reflectedErr)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratedCodecInvalidIPVersion(t *testing.T) {
+	b := []byte{0, 0, 0, 3, 10, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	_, generatedErr := (&ExtendedRouterFlow{}).DecodeXDR(bytes.NewReader(b))
+	_, reflectedErr := decodeReflect(bytes.NewReader(b), &ExtendedRouterFlow{})
+	if generatedErr == nil || reflectedErr == nil || generatedErr.Error() != reflectedErr.Error() {
+		t.Errorf("expected the same error, got %v and %v", generatedErr, reflectedErr)
+	}
+
+	if err := (ExtendedRouterFlow{NextHopType: 3}).EncodeXDR(&bytes.Buffer{}); err == nil {
+		t.Error("expected an error encoding an invalid IP version")
+	}
+}
+
+func TestMaximumLength(t *testing.T) {
+	// An HTTP request declaring a 1 GB URI
+	b := []byte{0, 0, 0, 1, 0, 0, 0x03, 0xe9, 0x40, 0, 0, 0, '/'}
+
+	_, generatedErr := (&HTTPRequestFlow{}).DecodeXDR(bytes.NewReader(b))
+	_, reflectedErr := decodeReflect(bytes.NewReader(b), &HTTPRequestFlow{})
+	if !errors.Is(generatedErr, ErrMaximumLength) || !errors.Is(reflectedErr, ErrMaximumLength) || generatedErr.Error() != reflectedErr.Error() {
+		t.Errorf("expected the same %v, got %v and %v", ErrMaximumLength, generatedErr, reflectedErr)
+	}
+
+	if _, err := DecodeFlow(bytes.NewReader(b), TypeHTTPRequestFlowRecord); !errors.Is(err, ErrMaximumLength) {
+		t.Errorf("expected %v, got %v", ErrMaximumLength, err)
+	}
+
+	// Fields without a maxLength tag are bounded by MaximumRecordLength
+	b = []byte{0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}
+	if _, err := (&ExtendedGatewayFlow{}).DecodeXDR(bytes.NewReader(b)); !errors.Is(err, ErrMaximumLength) {
+		t.Errorf("expected %v, got %v", ErrMaximumLength, err)
+	}
+	if _, err := decodeReflect(bytes.NewReader(b), &ExtendedGatewayFlow{}); !errors.Is(err, ErrMaximumLength) {
+		t.Errorf("expected %v, got %v", ErrMaximumLength, err)
+	}
+
+	f := HTTPRequestFlow{URILen: 256, URI: make(XDRString, 256)}
+	if err := f.EncodeXDR(&bytes.Buffer{}); !errors.Is(err, ErrMaximumLength) {
+		t.Errorf("expected %v, got %v", ErrMaximumLength, err)
+	}
+	if err := encodeReflect(&bytes.Buffer{}, f); !errors.Is(err, ErrMaximumLength) {
+		t.Errorf("expected %v, got %v", ErrMaximumLength, err)
+	}
+
+	// The maximum itself is fine
+	f = HTTPRequestFlow{URILen: 255, URI: make(XDRString, 255)}
+	buf := &bytes.Buffer{}
+	if err := f.EncodeXDR(buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&HTTPRequestFlow{}).DecodeXDR(buf); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkDecodeXDR(b *testing.B) {
+	buf := &bytes.Buffer{}
+	f := HTTPRequestFlow{URILen: 5, URI: XDRString("/path"), HostLen: 11, Host: XDRString("example.com")}
+	f.EncodeXDR(buf)
+
+	b.Run("generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			(&HTTPRequestFlow{}).DecodeXDR(bytes.NewReader(buf.Bytes()))
+		}
+	})
+
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			decodeReflect(bytes.NewReader(buf.Bytes()), &HTTPRequestFlow{})
+		}
+	})
+}
+
+// testClearLengths sets the lengthLookUp fields of the struct v to zero
+func testClearLengths(v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+
+		switch field.Kind() {
+		case reflect.Struct:
+			testClearLengths(field)
+		case reflect.Slice:
+			if lookup := t.Field(i).Tag.Get("lengthLookUp"); lookup != "" {
+				v.FieldByName(lookup).SetUint(0)
+			}
+			if field.Type().Elem().Kind() == reflect.Struct {
+				for x := 0; x < field.Len(); x++ {
+					testClearLengths(field.Index(x))
+				}
+			}
+		}
+	}
+}
+
+// testHasLengths returns whether the struct type t or one of its nested structs has lengthLookUp fields
+func testHasLengths(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		if sf.Tag.Get("lengthLookUp") != "" {
+			return true
+		}
+		if sf.Type.Kind() == reflect.Struct && testHasLengths(sf.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestEncodeUnsetLengths(t *testing.T) {
+	// The same random values for the records with and without their lengths
+	rnd, rndUnset := rand.New(rand.NewSource(1)), rand.New(rand.NewSource(1))
+
+	for _, rec := range testGeneratedRecords(t) {
+		if !testHasLengths(reflect.TypeOf(rec)) {
+			continue
+		}
+
+		recordType := uint32(rec.(Record).RecordType())
+
+		decode := DecodeCounter
+		if reflect.TypeOf(flowRecordTypes[recordType]) == reflect.TypeOf(rec) {
+			decode = DecodeFlow
+		}
+
+		for i := 0; i < 20; i++ {
+			value := reflect.New(reflect.TypeOf(rec)).Elem()
+			testFill(rnd, value)
+			unset := reflect.New(reflect.TypeOf(rec)).Elem()
+			testFill(rndUnset, unset)
+			testClearLengths(unset)
+
+			expected, generated, reflected := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+			if err := Encode(expected, value.Interface()); err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+			if err := Encode(generated, unset.Interface()); err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+			if err := encodeReflect(reflected, unset.Interface()); err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+
+			if !bytes.Equal(generated.Bytes(), expected.Bytes()) || !bytes.Equal(reflected.Bytes(), expected.Bytes()) {
+				t.Fatalf("%T: expected the encoding\n%x\n, got\n%x\nand\n%x", rec, expected.Bytes(), generated.Bytes(), reflected.Bytes())
+			}
+
+			// The record length matches the encoded record
+			record := &bytes.Buffer{}
+			if err := unset.Interface().(Record).Encode(record); err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+			b := record.Bytes()
+			if length := binary.BigEndian.Uint32(b[4:8]); int(length) != len(b)-8 {
+				t.Fatalf("%T: expected the record length %d, got %d", rec, len(b)-8, length)
+			}
+
+			decoded, err := decode(bytes.NewReader(b[8:]), recordType)
+			if err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+			expectedRecord, err := decode(bytes.NewReader(expected.Bytes()), recordType)
+			if err != nil {
+				t.Fatalf("%T: %v", rec, err)
+			}
+			if !reflect.DeepEqual(decoded, expectedRecord) {
+				t.Fatalf("%T: expected\n%+#v\n, got\n%+#v", rec, expectedRecord, decoded)
+			}
+		}
+	}
+}
+
+func TestRegistryDecodersMatchReflection(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, rec := range testGeneratedRecords(t) {
+		recordType := uint32(rec.(Record).RecordType())
+
+		decode, decodeXDR, decodeBytes := DecodeCounter, decodeCounterXDR, DecodeCounterBytes
+		if reflect.TypeOf(flowRecordTypes[recordType]) == reflect.TypeOf(rec) {
+			decode, decodeXDR, decodeBytes = DecodeFlow, decodeFlowXDR, DecodeFlowBytes
+		}
+
+		value := reflect.New(reflect.TypeOf(rec)).Elem()
+		testFill(rnd, value)
+
+		buf := &bytes.Buffer{}
+		if err := Encode(buf, value.Interface()); err != nil {
+			t.Fatalf("%T: %v", rec, err)
+		}
+		b := buf.Bytes()
+
+		if _, found, _ := decodeXDR(bytes.NewReader(b), recordType); !found {
+			t.Errorf("%T is not decoded by its generated decoder", rec)
+			continue
+		}
+
+		decoded, err := decode(bytes.NewReader(b), recordType)
+		if err != nil {
+			t.Fatalf("%T: %v", rec, err)
+		}
+
+		expected := reflect.New(reflect.TypeOf(rec))
+		if _, err := decodeReflect(bytes.NewReader(b), expected.Interface()); err != nil {
+			t.Fatalf("%T: %v", rec, err)
+		}
+		if p, ok := expected.Interface().(PostDecoder); ok {
+			p.PostDecode()
+		}
+
+		if !reflect.DeepEqual(decoded, expected.Elem().Interface()) {
+			t.Errorf("%T: expected\n%+#v\n, got\n%+#v", rec, expected.Elem().Interface(), decoded)
+		}
+
+		// The records decoded in place are pooled pointers
+		inPlace, n, err := decodeBytes(b, recordType)
+		if err != nil || n != len(b) {
+			t.Fatalf("%T: decoded %d of %d bytes in place: %v", rec, n, len(b), err)
+		}
+		if !reflect.DeepEqual(inPlace, expected.Interface()) {
+			t.Errorf("%T: expected\n%+#v\n in place, got\n%+#v", rec, expected.Interface(), inPlace)
+		}
+		ReleaseRecord(inPlace)
+	}
+}
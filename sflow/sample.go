@@ -3,8 +3,8 @@ package sflow
 import (
 	"encoding/binary"
 	"errors"
-	"github.com/fstelzer/sflow/records"
 	"io"
+	"sflowbeat/sflow/records"
 )
 
 const (
@@ -24,7 +24,10 @@ type Sample interface {
 	encode(w io.Writer) error
 }
 
-func decodeSample(r io.ReadSeeker) (Sample, error) {
+// decodeSample decodes the next sample of a datagram from its declared length. Samples which
+// fail to decode are reported to rep and skipped, in which case decodeSample returns a nil Sample.
+// An error is only returned if the datagram ends before the sample header.
+func decodeSample(r io.ReadSeeker, rep *reporter) (Sample, error) {
 	format, length, err := uint32(0), uint32(0), error(nil)
 
 	err = binary.Read(r, binary.BigEndian, &format)
@@ -37,19 +40,38 @@ func decodeSample(r io.ReadSeeker) (Sample, error) {
 		return nil, err
 	}
 
+	if rep != nil {
+		rep.sampleType = format
+	}
+
+	sr, err := newBoundedReader(r, length)
+	if err != nil {
+		return nil, err
+	}
+
+	var sample Sample
+
 	switch format {
 	case TypeCounterSample:
-		return decodeCounterSample(r)
-
+		sample, err = decodeCounterSample(sr, rep)
 	case TypeFlowSample:
-		return decodeFlowSample(r)
-
+		sample, err = decodeFlowSample(sr, rep)
 	default:
-		_, err = r.Seek(int64(length), 1)
-		if err != nil {
-			return nil, err
-		}
+		err = ErrUnknownSampleType
+	}
+
+	if err != ErrUnknownSampleType {
+		err = sr.check(format, length, err)
+	}
+	rep.checkSample(length, sr.start, sr.consumed(), err)
 
-		return nil, ErrUnknownSampleType
+	// Continue with the next sample in any case
+	if skipErr := sr.skip(); skipErr != nil {
+		return nil, skipErr
 	}
+	if err != nil {
+		return nil, nil
+	}
+
+	return sample, nil
 }
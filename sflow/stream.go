@@ -0,0 +1,449 @@
+package sflow
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"sflowbeat/sflow/records"
+)
+
+// MaximumDatagramLength is the maximum length of a datagram in a stream, the maximum UDP payload
+const MaximumDatagramLength = 65535
+
+// DefaultPort is the UDP port sFlow agents send their datagrams to
+const DefaultPort = 6343
+
+var (
+	ErrUnknownStreamFormat = errors.New("sflow: unknown stream format")
+	ErrCorruptFrame        = errors.New("sflow: corrupt stream frame")
+	ErrTruncatedPacket     = errors.New("sflow: packet truncated by the capture")
+	ErrFragmentedPacket    = errors.New("sflow: fragmented packet")
+)
+
+// StreamError describes a datagram of a stream which could not be read or decoded.
+// Reading continues with the next datagram of the stream.
+type StreamError struct {
+	Offset int64 /* offset of the datagram in the stream */
+	Err    error
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("sflow: datagram at offset %d: %s", e.Offset, e.Err)
+}
+
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// Frames written by StreamWriter:
+//
+//	uint32   frameMagic
+//	int64    time the datagram was received in nanoseconds since the Unix epoch
+//	[16]byte source IP address, IPv4 addresses are IPv4-mapped IPv6 addresses
+//	uint32   source port
+//	uint32   length of the datagram
+//	         datagram
+const (
+	frameMagic        = 0x73466c77 /* "sFlw" */
+	frameHeaderLength = 36
+)
+
+// pcap file format, see https://wiki.wireshark.org/Development/LibpcapFileFormat
+const (
+	pcapMagic              = 0xa1b2c3d4
+	pcapMagicNano          = 0xa1b23c4d
+	pcapngMagic            = 0x0a0d0d0a
+	pcapHeaderLength       = 24
+	pcapRecordHeaderLength = 16
+
+	// Larger packets are rejected even if the snapshot length of the capture allows them
+	pcapMaximumPacketLength = 262144
+
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeLoop     = 108
+	linkTypeLinuxSLL = 113
+	linkTypeIPv4     = 228
+	linkTypeIPv6     = 229
+)
+
+// StreamReader reads a sequence of datagrams from a stream, which is either a sequence of frames
+// written by StreamWriter or a pcap capture of the sFlow UDP packets. The format is detected from the
+// start of the stream.
+type StreamReader struct {
+	r       *bufio.Reader
+	offset  int64
+	decoder *Decoder
+	next    func() (time.Time, *net.UDPAddr, []byte, error)
+	err     error
+
+	// pcap captures
+	order    binary.ByteOrder
+	nano     bool
+	linkType uint32
+	port     uint16
+}
+
+func NewStreamReader(r io.Reader) *StreamReader {
+	return &StreamReader{
+		r:       bufio.NewReaderSize(r, frameHeaderLength+MaximumDatagramLength),
+		decoder: NewDecoder(nil),
+		port:    DefaultPort,
+	}
+}
+
+// SetPort sets the UDP destination port of the sFlow packets in pcap captures, DefaultPort unless it is set.
+// UDP packets to other ports are skipped. With port 0 the UDP packets to all ports are read as sFlow.
+func (s *StreamReader) SetPort(port uint16) {
+	s.port = port
+}
+
+// SetDiagnostics sets the receiver of the Diagnostic events of the decoded datagrams, see Decoder.SetDiagnostics
+func (s *StreamReader) SetDiagnostics(diagnostics Diagnostics) {
+	s.decoder.SetDiagnostics(diagnostics)
+}
+
+// Next returns the next datagram of the stream with the time it was received and its source.
+// A datagram which cannot be read or decoded is returned as a *StreamError, Next can be called again
+// to continue with the rest of the stream. Any other error ends the stream and is returned by all
+// further calls, io.EOF at the end of the stream.
+func (s *StreamReader) Next() (time.Time, *net.UDPAddr, *Datagram, error) {
+	if s.next == nil && s.err == nil {
+		s.err = s.detect()
+	}
+
+	for s.err == nil {
+		offset := s.offset
+
+		receivedAt, source, b, err := s.next()
+		if err != nil {
+			if _, ok := err.(*StreamError); ok {
+				return receivedAt, source, nil, err
+			}
+			s.err = err
+			break
+		}
+
+		// Packets of a capture which are not sFlow UDP packets
+		if b == nil {
+			continue
+		}
+
+		dgram, err := s.decoder.DecodeBytes(b)
+		if err != nil {
+			return receivedAt, source, nil, &StreamError{Offset: offset, Err: err}
+		}
+
+		return receivedAt, source, dgram, nil
+	}
+
+	return time.Time{}, nil, nil, s.err
+}
+
+func (s *StreamReader) read(b []byte) error {
+	n, err := io.ReadFull(s.r, b)
+	s.offset += int64(n)
+	return err
+}
+
+func (s *StreamReader) discard(n int) {
+	n, _ = s.r.Discard(n)
+	s.offset += int64(n)
+}
+
+// detect determines the format of the stream
+func (s *StreamReader) detect() error {
+	b, err := s.r.Peek(4)
+	if err != nil {
+		if err == io.EOF && len(b) > 0 {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	switch binary.BigEndian.Uint32(b) {
+	case frameMagic:
+		s.next = s.nextFrame
+		return nil
+	case pcapngMagic:
+		return fmt.Errorf("%w: pcapng captures are not supported, convert them to pcap", ErrUnknownStreamFormat)
+	}
+
+	switch {
+	case binary.BigEndian.Uint32(b) == pcapMagic || binary.BigEndian.Uint32(b) == pcapMagicNano:
+		s.order = binary.BigEndian
+	case binary.LittleEndian.Uint32(b) == pcapMagic || binary.LittleEndian.Uint32(b) == pcapMagicNano:
+		s.order = binary.LittleEndian
+	default:
+		return ErrUnknownStreamFormat
+	}
+
+	header := make([]byte, pcapHeaderLength)
+	if err := s.read(header); err != nil {
+		return err
+	}
+
+	s.nano = s.order.Uint32(header) == pcapMagicNano
+	s.linkType = s.order.Uint32(header[20:]) & 0x0fffffff
+
+	switch s.linkType {
+	case linkTypeNull, linkTypeEthernet, linkTypeRaw, linkTypeLoop, linkTypeLinuxSLL, linkTypeIPv4, linkTypeIPv6:
+	default:
+		return fmt.Errorf("%w: pcap link type %d", ErrUnknownStreamFormat, s.linkType)
+	}
+
+	s.next = s.nextPacket
+	return nil
+}
+
+// nextFrame reads the next frame written by StreamWriter.
+// If the frame header is corrupt, the stream is skipped up to the next frame.
+func (s *StreamReader) nextFrame() (time.Time, *net.UDPAddr, []byte, error) {
+	offset := s.offset
+
+	header, err := s.r.Peek(frameHeaderLength)
+	if err != nil {
+		if err == io.EOF && len(header) == 0 {
+			return time.Time{}, nil, nil, io.EOF
+		}
+		if err != io.EOF {
+			return time.Time{}, nil, nil, err
+		}
+	}
+
+	if len(header) < frameHeaderLength || binary.BigEndian.Uint32(header) != frameMagic ||
+		binary.BigEndian.Uint32(header[32:]) > MaximumDatagramLength {
+		return time.Time{}, nil, nil, &StreamError{Offset: offset, Err: fmt.Errorf("%w: skipped %d bytes", ErrCorruptFrame, s.resync())}
+	}
+
+	receivedAt := time.Unix(0, int64(binary.BigEndian.Uint64(header[4:])))
+
+	// The source of frames written without one is left out
+	var source *net.UDPAddr
+	if ip, port := net.IP(header[12:28]), binary.BigEndian.Uint32(header[28:]); !ip.IsUnspecified() || port != 0 {
+		source = &net.UDPAddr{IP: normalizeIP(append(net.IP(nil), ip...)), Port: int(port)}
+	}
+	length := int(binary.BigEndian.Uint32(header[32:]))
+	s.discard(frameHeaderLength)
+
+	b := make([]byte, length)
+	if err := s.read(b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return receivedAt, source, nil, &StreamError{Offset: offset, Err: err}
+	}
+
+	return receivedAt, source, b, nil
+}
+
+// resync skips the stream up to the next frame magic and returns the number of bytes skipped
+func (s *StreamReader) resync() int64 {
+	start := s.offset
+
+	s.discard(1)
+	for {
+		b, err := s.r.Peek(4)
+		if err != nil {
+			s.discard(len(b))
+			break
+		}
+		if binary.BigEndian.Uint32(b) == frameMagic {
+			break
+		}
+		s.discard(1)
+	}
+
+	return s.offset - start
+}
+
+// nextPacket reads the next packet of a pcap capture. The data of packets which are not UDP packets
+// to the sFlow port is nil.
+func (s *StreamReader) nextPacket() (time.Time, *net.UDPAddr, []byte, error) {
+	offset := s.offset
+
+	header := make([]byte, pcapRecordHeaderLength)
+	if err := s.read(header); err != nil {
+		return time.Time{}, nil, nil, err
+	}
+
+	sec, frac := s.order.Uint32(header), s.order.Uint32(header[4:])
+	if !s.nano {
+		frac *= 1000
+	}
+	receivedAt := time.Unix(int64(sec), int64(frac))
+
+	capturedLength, length := s.order.Uint32(header[8:]), s.order.Uint32(header[12:])
+
+	// The next packet cannot be found if the length is wrong
+	if capturedLength > pcapMaximumPacketLength {
+		return receivedAt, nil, nil, fmt.Errorf("sflow: pcap packet at offset %d with length %d", offset, capturedLength)
+	}
+
+	data := make([]byte, capturedLength)
+	if err := s.read(data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return receivedAt, nil, nil, err
+	}
+
+	source, payload, err := s.udpPayload(data)
+	if err == nil && payload != nil && capturedLength < length {
+		err = ErrTruncatedPacket
+	}
+	if err != nil {
+		return receivedAt, source, nil, &StreamError{Offset: offset, Err: err}
+	}
+
+	return receivedAt, source, payload, nil
+}
+
+// udpPayload returns the source and payload of a captured UDP packet, or no payload if it is no UDP packet
+// to the sFlow port
+func (s *StreamReader) udpPayload(data []byte) (*net.UDPAddr, []byte, error) {
+	var etherType uint16
+
+	switch s.linkType {
+	case linkTypeNull, linkTypeLoop:
+		if len(data) < 4 {
+			return nil, nil, nil
+		}
+
+		family := s.order.Uint32(data)
+		if s.linkType == linkTypeLoop {
+			family = binary.BigEndian.Uint32(data)
+		}
+
+		switch family {
+		case 2:
+			etherType = 0x0800
+		case 24, 28, 30: // AF_INET6 of the BSDs, FreeBSD and Darwin
+			etherType = 0x86dd
+		}
+		data = data[4:]
+	case linkTypeEthernet:
+		if len(data) < 14 {
+			return nil, nil, nil
+		}
+
+		etherType, data = binary.BigEndian.Uint16(data[12:]), data[14:]
+		for (etherType == 0x8100 || etherType == 0x88a8) && len(data) >= 4 {
+			etherType, data = binary.BigEndian.Uint16(data[2:]), data[4:]
+		}
+	case linkTypeLinuxSLL:
+		if len(data) < 16 {
+			return nil, nil, nil
+		}
+		etherType, data = binary.BigEndian.Uint16(data[14:]), data[16:]
+	case linkTypeRaw, linkTypeIPv4, linkTypeIPv6:
+		if len(data) > 0 {
+			switch data[0] >> 4 {
+			case 4:
+				etherType = 0x0800
+			case 6:
+				etherType = 0x86dd
+			}
+		}
+	}
+
+	var ip net.IP
+
+	switch etherType {
+	case 0x0800:
+		if len(data) < 20 || data[9] != records.IPProtocolUDP {
+			return nil, nil, nil
+		}
+
+		ip = append(net.IP(nil), data[12:16]...)
+		if binary.BigEndian.Uint16(data[6:])&0x3fff != 0 {
+			return &net.UDPAddr{IP: ip}, nil, ErrFragmentedPacket
+		}
+
+		headerLength := int(data[0]&0x0f) * 4
+		if headerLength < 20 || len(data) < headerLength {
+			return nil, nil, nil
+		}
+		data = data[headerLength:]
+	case 0x86dd:
+		if len(data) < 40 {
+			return nil, nil, nil
+		}
+
+		ip = append(net.IP(nil), data[8:24]...)
+		switch data[6] {
+		case records.IPProtocolUDP:
+		case 44: // fragment header
+			return &net.UDPAddr{IP: ip}, nil, ErrFragmentedPacket
+		default:
+			return nil, nil, nil
+		}
+		data = data[40:]
+	default:
+		return nil, nil, nil
+	}
+
+	if len(data) < 8 {
+		return &net.UDPAddr{IP: ip}, nil, ErrTruncatedPacket
+	}
+
+	// Other UDP traffic of the capture
+	if port := binary.BigEndian.Uint16(data[2:]); s.port != 0 && port != s.port {
+		return nil, nil, nil
+	}
+
+	source := &net.UDPAddr{IP: ip, Port: int(binary.BigEndian.Uint16(data))}
+
+	length := int(binary.BigEndian.Uint16(data[4:]))
+	if length < 8 || length > len(data) {
+		return source, nil, ErrTruncatedPacket
+	}
+
+	return source, data[8:length], nil
+}
+
+// StreamWriter writes datagrams as frames which can be read by StreamReader
+type StreamWriter struct {
+	w io.Writer
+}
+
+func NewStreamWriter(w io.Writer) *StreamWriter {
+	return &StreamWriter{w: w}
+}
+
+// Write writes a frame holding the datagram received at receivedAt from source, which may be nil
+func (s *StreamWriter) Write(receivedAt time.Time, source *net.UDPAddr, datagram []byte) error {
+	if len(datagram) > MaximumDatagramLength {
+		return fmt.Errorf("sflow: datagram length more than %d: %d", MaximumDatagramLength, len(datagram))
+	}
+
+	header := make([]byte, frameHeaderLength)
+	binary.BigEndian.PutUint32(header, frameMagic)
+	binary.BigEndian.PutUint64(header[4:], uint64(receivedAt.UnixNano()))
+	if source != nil {
+		copy(header[12:28], source.IP.To16())
+		binary.BigEndian.PutUint32(header[28:], uint32(source.Port))
+	}
+	binary.BigEndian.PutUint32(header[32:], uint32(len(datagram)))
+
+	if _, err := s.w.Write(header); err != nil {
+		return err
+	}
+
+	_, err := s.w.Write(datagram)
+	return err
+}
+
+// normalizeIP returns IPv4 addresses in their 4 byte representation
+func normalizeIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip
+}
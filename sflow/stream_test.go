@@ -0,0 +1,262 @@
+package sflow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testReadDump(t *testing.T, dump string) ([]byte, *Datagram) {
+	b, err := ioutil.ReadFile(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dgram, err := NewDecoder(bytes.NewReader(b)).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b, dgram
+}
+
+func TestStreamFrames(t *testing.T) {
+	counter, counterDgram := testReadDump(t, "_test/counter_sample.dump")
+	flow, flowDgram := testReadDump(t, "_test/flow_sample.dump")
+
+	receivedAt := time.Unix(1600000000, 123456789)
+	source := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1).To4(), Port: 6343}
+	source6 := &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 6343}
+
+	buf := &bytes.Buffer{}
+	w := NewStreamWriter(buf)
+	w.Write(receivedAt, source, counter)
+	// Garbage between frames
+	buf.Write([]byte{1, 2, 3, 4, 5})
+	w.Write(receivedAt, source6, flow)
+	// A datagram which cannot be decoded
+	w.Write(receivedAt, nil, []byte{0, 0, 0, 4})
+	w.Write(receivedAt, source, flow)
+
+	r := NewStreamReader(bytes.NewReader(buf.Bytes()))
+
+	expected := []struct {
+		source *net.UDPAddr
+		dgram  *Datagram
+		err    error
+	}{
+		{source, counterDgram, nil},
+		{nil, nil, ErrCorruptFrame},
+		{source6, flowDgram, nil},
+		{nil, nil, ErrUnsupportedDatagramVersion},
+		{source, flowDgram, nil},
+	}
+
+	for i, e := range expected {
+		at, src, dgram, err := r.Next()
+
+		if e.err != nil {
+			var streamErr *StreamError
+			if !errors.As(err, &streamErr) || !errors.Is(err, e.err) {
+				t.Fatalf("%d: expected a StreamError for %v, got %v", i, e.err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if !at.Equal(receivedAt) || !reflect.DeepEqual(src, e.source) || !reflect.DeepEqual(testRecordValues(dgram), e.dgram) {
+			t.Errorf("%d: expected %v from %v, got %v from %v", i, receivedAt, e.source, at, src)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, _, err := r.Next(); err != io.EOF {
+			t.Errorf("expected %v, got %v", io.EOF, err)
+		}
+	}
+}
+
+func TestStreamTruncatedFrame(t *testing.T) {
+	counter, _ := testReadDump(t, "_test/counter_sample.dump")
+
+	buf := &bytes.Buffer{}
+	NewStreamWriter(buf).Write(time.Now(), nil, counter)
+
+	r := NewStreamReader(bytes.NewReader(buf.Bytes()[:buf.Len()-10]))
+	if _, _, _, err := r.Next(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+	if _, _, _, err := r.Next(); err != io.EOF {
+		t.Errorf("expected %v, got %v", io.EOF, err)
+	}
+}
+
+// testPcap returns a little endian pcap capture with microsecond timestamps of the given link type and packets
+func testPcap(linkType uint32, packets ...[]byte) []byte {
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, []uint32{pcapMagic, 0x00040002, 0, 0, 65535, linkType})
+
+	for i, p := range packets {
+		binary.Write(buf, binary.LittleEndian, []uint32{1600000000, uint32(i), uint32(len(p)), uint32(len(p))})
+		buf.Write(p)
+	}
+
+	return buf.Bytes()
+}
+
+// testUDPv4 returns an Ethernet frame with a VLAN tag holding an IPv4 UDP packet
+func testUDPv4(src net.IP, port, dstPort uint16, protocol byte, payload []byte) []byte {
+	buf := &bytes.Buffer{}
+	buf.Write(make([]byte, 12))
+	binary.Write(buf, binary.BigEndian, []uint16{0x8100, 10, 0x0800})
+
+	ip := make([]byte, 20)
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:], uint16(28+len(payload)))
+	ip[8], ip[9] = 64, protocol
+	copy(ip[12:], src.To4())
+	buf.Write(ip)
+
+	binary.Write(buf, binary.BigEndian, []uint16{port, dstPort, uint16(8 + len(payload)), 0})
+	buf.Write(payload)
+
+	return buf.Bytes()
+}
+
+func TestStreamPcap(t *testing.T) {
+	counter, counterDgram := testReadDump(t, "_test/counter_sample.dump")
+	flow, flowDgram := testReadDump(t, "_test/flow_sample.dump")
+
+	// An IPv6 packet on a raw IP link
+	ip6 := make([]byte, 48)
+	ip6[0] = 0x60
+	binary.BigEndian.PutUint16(ip6[4:], uint16(8+len(flow)))
+	ip6[6] = 17
+	copy(ip6[8:], net.ParseIP("2001:db8::1"))
+	binary.BigEndian.PutUint16(ip6[40:], 1234)
+	binary.BigEndian.PutUint16(ip6[42:], DefaultPort)
+	binary.BigEndian.PutUint16(ip6[44:], uint16(8+len(flow)))
+	ip6 = append(ip6, flow...)
+
+	for _, c := range []struct {
+		name     string
+		capture  []byte
+		expected []*Datagram
+		sources  []*net.UDPAddr
+	}{
+		{
+			name: "ethernet",
+			capture: testPcap(linkTypeEthernet,
+				testUDPv4(net.IPv4(192, 0, 2, 1), 1234, DefaultPort, 17, counter),
+				// Not UDP
+				testUDPv4(net.IPv4(192, 0, 2, 1), 1234, DefaultPort, 6, counter),
+				// Other UDP traffic
+				testUDPv4(net.IPv4(192, 0, 2, 4), 53, 40000, 17, []byte{0x12, 0x34, 0x81, 0x80}),
+				testUDPv4(net.IPv4(192, 0, 2, 5), 40000, 123, 17, flow),
+				// Not sFlow
+				testUDPv4(net.IPv4(192, 0, 2, 2), 1234, DefaultPort, 17, []byte{1, 2, 3}),
+				testUDPv4(net.IPv4(192, 0, 2, 3), 4321, DefaultPort, 17, flow),
+			),
+			expected: []*Datagram{counterDgram, nil, flowDgram},
+			sources: []*net.UDPAddr{
+				{IP: net.IPv4(192, 0, 2, 1).To4(), Port: 1234},
+				{IP: net.IPv4(192, 0, 2, 2).To4(), Port: 1234},
+				{IP: net.IPv4(192, 0, 2, 3).To4(), Port: 4321},
+			},
+		},
+		{
+			name:     "raw",
+			capture:  testPcap(linkTypeRaw, ip6),
+			expected: []*Datagram{flowDgram},
+			sources:  []*net.UDPAddr{{IP: net.ParseIP("2001:db8::1"), Port: 1234}},
+		},
+	} {
+		r := NewStreamReader(bytes.NewReader(c.capture))
+
+		for i, expected := range c.expected {
+			at, src, dgram, err := r.Next()
+
+			if !reflect.DeepEqual(src, c.sources[i]) {
+				t.Errorf("%s %d: expected the source %v, got %v", c.name, i, c.sources[i], src)
+			}
+
+			if expected == nil {
+				var streamErr *StreamError
+				if !errors.As(err, &streamErr) {
+					t.Errorf("%s %d: expected a StreamError, got %v", c.name, i, err)
+				}
+				continue
+			}
+
+			if err != nil {
+				t.Fatalf("%s %d: %v", c.name, i, err)
+			}
+			if at.Unix() != 1600000000 || !reflect.DeepEqual(testRecordValues(dgram), expected) {
+				t.Errorf("%s %d: unexpected datagram %v received at %v", c.name, i, dgram, at)
+			}
+		}
+
+		if _, _, _, err := r.Next(); err != io.EOF {
+			t.Errorf("%s: expected %v, got %v", c.name, io.EOF, err)
+		}
+	}
+}
+
+func TestStreamPcapPort(t *testing.T) {
+	counter, counterDgram := testReadDump(t, "_test/counter_sample.dump")
+	flow, flowDgram := testReadDump(t, "_test/flow_sample.dump")
+
+	capture := testPcap(linkTypeEthernet,
+		testUDPv4(net.IPv4(192, 0, 2, 1), 1234, 9999, 17, counter),
+		testUDPv4(net.IPv4(192, 0, 2, 1), 1234, DefaultPort, 17, flow),
+	)
+
+	for _, c := range []struct {
+		port     uint16
+		expected []*Datagram
+	}{
+		{9999, []*Datagram{counterDgram}},
+		{0, []*Datagram{counterDgram, flowDgram}},
+	} {
+		r := NewStreamReader(bytes.NewReader(capture))
+		r.SetPort(c.port)
+
+		for i, expected := range c.expected {
+			_, _, dgram, err := r.Next()
+			if err != nil {
+				t.Fatalf("port %d, %d: %v", c.port, i, err)
+			}
+			if !reflect.DeepEqual(testRecordValues(dgram), expected) {
+				t.Errorf("port %d, %d: unexpected datagram %v", c.port, i, dgram)
+			}
+		}
+
+		if _, _, _, err := r.Next(); err != io.EOF {
+			t.Errorf("port %d: expected %v, got %v", c.port, io.EOF, err)
+		}
+	}
+}
+
+func TestStreamUnknownFormat(t *testing.T) {
+	for _, b := range [][]byte{
+		{1, 2, 3, 4, 5},
+		{0x0a, 0x0d, 0x0d, 0x0a, 0, 0, 0, 0},
+		testPcap(147),
+	} {
+		if _, _, _, err := NewStreamReader(bytes.NewReader(b)).Next(); !errors.Is(err, ErrUnknownStreamFormat) {
+			t.Errorf("expected %v, got %v", ErrUnknownStreamFormat, err)
+		}
+	}
+
+	if _, _, _, err := NewStreamReader(bytes.NewReader(nil)).Next(); err != io.EOF {
+		t.Errorf("expected %v, got %v", io.EOF, err)
+	}
+}